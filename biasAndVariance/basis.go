@@ -0,0 +1,98 @@
+package biasAndVariance
+
+import (
+	"errors"
+	"math"
+)
+
+// Basis is a hypothesis set linear in its parameters:
+// h(x) = Sum(w_i * basis[i](x))
+type Basis []GFunc
+
+// Common functions to build a basis.
+var (
+	One      GFunc = func(x float64) float64 { return 1 }
+	Identity GFunc = func(x float64) float64 { return x }
+	Square   GFunc = func(x float64) float64 { return x * x }
+)
+
+// PolynomialBasis returns the basis of polynomials of degree q: 1, x, ..., x^q
+func PolynomialBasis(q int) Basis {
+	basis := make(Basis, q+1)
+	for i := range basis {
+		d := float64(i)
+		basis[i] = func(x float64) float64 { return math.Pow(x, d) }
+	}
+	return basis
+}
+
+// Hypothesis returns the function h(x) = Sum(w_i * basis[i](x)).
+func (basis Basis) Hypothesis(w []float64) GFunc {
+	return func(x float64) float64 {
+		y := float64(0)
+		for i, phi := range basis {
+			y += w[i] * phi(x)
+		}
+		return y
+	}
+}
+
+// Fit returns the weights minimizing the squared error of the hypothesis on points (xs, ys)
+// by solving the normal equations (Z'Z)w = Z'y where Z[n][i] = basis[i](xs[n]).
+func (basis Basis) Fit(xs, ys []float64) ([]float64, error) {
+	k := len(basis)
+	if k == 0 {
+		return nil, errors.New("basis should have at least one function")
+	}
+	// augmented matrix [Z'Z | Z'y]
+	a := make([][]float64, k)
+	for i := range a {
+		a[i] = make([]float64, k+1)
+	}
+	for n := range xs {
+		z := make([]float64, k)
+		for i, phi := range basis {
+			z[i] = phi(xs[n])
+		}
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				a[i][j] += z[i] * z[j]
+			}
+			a[i][k] += z[i] * ys[n]
+		}
+	}
+	return solve(a)
+}
+
+// solve returns x such that A x = b given the augmented matrix [A | b],
+// using gaussian elimination with partial pivoting.
+func solve(a [][]float64) ([]float64, error) {
+	k := len(a)
+	for col := 0; col < k; col++ {
+		pivot := col
+		for row := col + 1; row < k; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-12 {
+			return nil, errors.New("singular matrix: not enough training points to fit the basis")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+		for row := col + 1; row < k; row++ {
+			f := a[row][col] / a[col][col]
+			for j := col; j <= k; j++ {
+				a[row][j] -= f * a[col][j]
+			}
+		}
+	}
+	x := make([]float64, k)
+	for row := k - 1; row >= 0; row-- {
+		sum := a[row][k]
+		for j := row + 1; j < k; j++ {
+			sum -= a[row][j] * x[j]
+		}
+		x[row] = sum / a[row][row]
+	}
+	return x, nil
+}
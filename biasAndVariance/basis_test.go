@@ -0,0 +1,55 @@
+package biasAndVariance
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFit(t *testing.T) {
+	tests := []struct {
+		name  string
+		basis Basis
+		xs    []float64
+		ys    []float64
+		want  []float64
+	}{
+		{"line", Basis{Identity, One}, []float64{-1, 0, 2}, []float64{-1, 1, 5}, []float64{2, 1}},
+		{"quadratic", PolynomialBasis(2), []float64{-1, 0, 1, 2}, []float64{6, 3, 2, 3}, []float64{3, -2, 1}},
+		{"constant is the mean", Basis{One}, []float64{-1, 0, 1}, []float64{1, 2, 6}, []float64{3}},
+		{"line through origin", Basis{Identity}, []float64{1, 2}, []float64{1, 2.5}, []float64{1.2}},
+	}
+	for _, tt := range tests {
+		w, err := tt.basis.Fit(tt.xs, tt.ys)
+		if err != nil {
+			t.Errorf("%s: Fit returned error %v", tt.name, err)
+			continue
+		}
+		for i := range tt.want {
+			if math.Abs(w[i]-tt.want[i]) > 1e-9 {
+				t.Errorf("%s: Fit == %v, want %v", tt.name, w, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFitSingular(t *testing.T) {
+	line := Basis{Identity, One}
+	if _, err := line.Fit([]float64{0.5}, []float64{1}); err == nil {
+		t.Errorf("fitting a line on a single point should return an error")
+	}
+	if _, err := line.Fit([]float64{0.5, 0.5}, []float64{1, 2}); err == nil {
+		t.Errorf("fitting a line on two equal points should return an error")
+	}
+	if _, err := (Basis{}).Fit([]float64{0.5}, []float64{1}); err == nil {
+		t.Errorf("fitting an empty basis should return an error")
+	}
+}
+
+func TestSolvePivoting(t *testing.T) {
+	// 0x + y = 2 and x + 0y = 3 needs a row swap.
+	x, err := solve([][]float64{{0, 1, 2}, {1, 0, 3}})
+	if err != nil || x[0] != 3 || x[1] != 2 {
+		t.Errorf("solve == %v, %v, want [3 2]", x, err)
+	}
+}
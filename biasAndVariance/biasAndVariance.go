@@ -1,8 +1,10 @@
 package biasAndVariance
 
 import (
+	"errors"
 	"fmt"
 	"math"
+	"math/rand"
 
 	"github.com/santiaago/ml/linear"
 )
@@ -18,7 +20,27 @@ type BiasAndVariance struct {
 	Variance            float64
 	Slope               float64
 	Constant            float64
-	ThroughOrigin       bool // defines if hypothesis function goes through origin or not. ie: h(x) = ax or h(x) = ax + b
+	ThroughOrigin       bool           // defines if hypothesis function goes through origin or not. ie: h(x) = ax or h(x) = ax + b
+	Weights             []float64      // coefficients of the average hypothesis gBar in the basis used by Learn.
+	Quadrature          int            // when positive, Ex is computed with a Gauss-Legendre quadrature of this many nodes instead of random draws.
+	Rand                *rand.Rand     // when set, the random points are drawn from this source so that results are reproducible.
+	Sigma               float64        // standard deviation of the gaussian noise added to the target, no noise when 0.
+	NoiseFunction       func() float64 // when set, draws the zero mean noise added to the target instead of the gaussian noise.
+	Noise               float64        // variance of the noise σ² = E[ε²].
+	TestExampleSize     int            // number of noisy held-out points used to measure Eout of each hypothesis, Eout is not measured when 0.
+	Eout                float64        // Eout measured on held-out noisy points, averaged over the runs.
+	nodes               []float64      // nodes of the quadrature.
+	weights             []float64      // weights of the quadrature.
+}
+
+// Decomposition holds the terms of the expected out of sample error:
+// Ed[Eout] = bias + variance + σ²
+type Decomposition struct {
+	Bias     float64
+	Variance float64
+	Noise    float64 // σ²
+	Expected float64 // bias + variance + σ²
+	Measured float64 // Eout measured on held-out noisy points.
 }
 
 func NewBiasAndVariance() *BiasAndVariance {
@@ -29,154 +51,190 @@ func NewBiasAndVariance() *BiasAndVariance {
 		return math.Sin(math.Pi * x)
 	}
 	bav.TrainingExampleSize = 2
+	bav.TestExampleSize = 100
 	bav.ThroughOrigin = true
 	return &bav
 }
 
-func (bav *BiasAndVariance) LearnLine() {
-	sumA := float64(0)
-	sumB := float64(0)
-
-	type GFunc func(x, a float64) float64
-	gs := make([]func(x, a, b float64) float64, bav.Runs)
-	as := make([]float64, bav.Runs)
-	bs := make([]float64, bav.Runs)
-
-	for i := 0; i < bav.Runs; i++ {
-		x1 := bav.Interval.RandFloat()
-		y1 := bav.TargetFunction(x1)
-
-		x2 := bav.Interval.RandFloat()
-		y2 := bav.TargetFunction(x2)
-
-		x := math.Abs(x2 - x1)
-		y := math.Abs(y2 - y1)
-
-		a := y / x
-		sumA += a
-		as[i] = a
-
-		g := func(ix, ia, ib float64) float64 { return ia*ix + ib }
-		gs[i] = g
-
-		if !bav.ThroughOrigin {
-			b := y1 - a*x1
-			sumB += b
-			bs[i] = b
+// LearnLine learns h(x) = ax or h(x) = ax + b depending on ThroughOrigin,
+// and sets Slope and Constant to the coefficients of the average hypothesis.
+func (bav *BiasAndVariance) LearnLine() error {
+	if bav.ThroughOrigin {
+		if err := bav.Learn(Basis{Identity}); err != nil {
+			return err
 		}
-
+		bav.Slope, bav.Constant = bav.Weights[0], 0
+		return nil
 	}
-
-	bav.Slope = sumA / float64(bav.Runs)
-	bav.Constant = sumB / float64(bav.Runs)
-
-	gBar := func(x float64) float64 {
-		return bav.Slope*x + bav.Constant
+	if err := bav.Learn(Basis{Identity, One}); err != nil {
+		return err
 	}
-
-	bav.Bias = bav.ComputeBias(gBar)
-	bav.Variance = bav.ComputeVariance(gBar, gs, as, bs)
+	bav.Slope, bav.Constant = bav.Weights[0], bav.Weights[1]
+	return nil
 }
 
-func (bav *BiasAndVariance) LearnConstant() {
-	sumB := float64(0)
-
-	type GFunc func(x float64, a float64) float64
-	gs := make([]func(x, a, b float64) float64, bav.Runs)
-	bs := make([]float64, bav.Runs)
-	for i := 0; i < bav.Runs; i++ {
-		x1 := bav.Interval.RandFloat()
-		y1 := bav.TargetFunction(x1)
-
-		x2 := bav.Interval.RandFloat()
-		y2 := bav.TargetFunction(x2)
-
-		b := (y1 + y2) / float64(2)
-
-		sumB += b
-
-		g := func(ix, ia, ib float64) float64 { return ib }
-		gs[i] = g
-		bs[i] = b
+// LearnConstant learns h(x) = b and sets Constant to the coefficient of the average hypothesis.
+func (bav *BiasAndVariance) LearnConstant() error {
+	if err := bav.Learn(Basis{One}); err != nil {
+		return err
 	}
+	bav.Slope, bav.Constant = 0, bav.Weights[0]
+	return nil
+}
 
-	bav.Constant = sumB / float64(bav.Runs)
-
-	gBar := func(x float64) float64 {
-		return bav.Constant
+// LearnQuadratic learns h(x) = ax^2 or h(x) = ax^2 + b depending on ThroughOrigin,
+// and sets Slope and Constant to the coefficients of the average hypothesis.
+func (bav *BiasAndVariance) LearnQuadratic() error {
+	if bav.ThroughOrigin {
+		if err := bav.Learn(Basis{Square}); err != nil {
+			return err
+		}
+		// this should definitely not be called slope but First Coefficient or something similar.
+		bav.Slope, bav.Constant = bav.Weights[0], 0
+		return nil
 	}
-
-	bav.Bias = bav.ComputeBias(gBar)
-	bav.Variance = bav.ComputeVariance(gBar, gs, make([]float64, bav.Runs), bs)
-
+	if err := bav.Learn(Basis{Square, One}); err != nil {
+		return err
+	}
+	bav.Slope, bav.Constant = bav.Weights[0], bav.Weights[1]
+	return nil
 }
 
-func (bav *BiasAndVariance) LearnQuadratic() {
-	sumA := float64(0)
-	sumB := float64(0)
-
-	type GFunc func(x float64, a float64) float64
-	gs := make([]func(x, a, b float64) float64, bav.Runs)
-	as := make([]float64, bav.Runs)
-	bs := make([]float64, bav.Runs)
-
+// Learn fits the hypothesis set defined by basis by least squares on TrainingExampleSize
+// random points of the target function, Runs times. It sets:
+// - Weights: the coefficients of the average hypothesis gBar, which is the average of the weights of every run
+// as the hypothesis set is linear in its parameters.
+// - Bias: Ex[(gBar(x) - f(x))^2]
+// - Variance: Ex[Ed[(g_d(x) - gBar(x))^2]]
+// - Noise: σ² the variance of the noise added to the target on every training point.
+// - Eout: the squared error of each hypothesis on TestExampleSize held-out noisy points, averaged over the runs.
+func (bav *BiasAndVariance) Learn(basis Basis) error {
+	if bav.Runs <= 0 {
+		return errors.New("bias and variance needs a positive number of runs")
+	}
+	gs := make([]GFunc, bav.Runs)
+	bav.Weights = make([]float64, len(basis))
 	for i := 0; i < bav.Runs; i++ {
-		x1 := bav.Interval.RandFloat()
-		y1 := bav.TargetFunction(x1)
+		xs := make([]float64, bav.TrainingExampleSize)
+		ys := make([]float64, bav.TrainingExampleSize)
+		for j := range xs {
+			xs[j] = bav.randFloat()
+			ys[j] = bav.TargetFunction(xs[j]) + bav.noise()
+		}
+		w, err := basis.Fit(xs, ys)
+		if err != nil {
+			return err
+		}
+		for k := range w {
+			bav.Weights[k] += w[k] / float64(bav.Runs)
+		}
+		gs[i] = basis.Hypothesis(w)
+	}
+	gBar := basis.Hypothesis(bav.Weights)
 
-		x2 := bav.Interval.RandFloat()
-		y2 := bav.TargetFunction(x2)
+	bav.Bias = bav.ComputeBias(gBar)
+	bav.Variance = bav.ComputeVariance(gBar, gs)
+	bav.Noise = bav.noiseVariance()
+	bav.Eout = bav.measureEout(gs)
+	return nil
+}
 
-		a := (y1 - y2) / (x1*x1 - x2*x2)
-		as[i] = a
+// Decomposition returns the decomposition of the expected out of sample error computed by Learn.
+func (bav *BiasAndVariance) Decomposition() Decomposition {
+	return Decomposition{
+		Bias:     bav.Bias,
+		Variance: bav.Variance,
+		Noise:    bav.Noise,
+		Expected: bav.Bias + bav.Variance + bav.Noise,
+		Measured: bav.Eout,
+	}
+}
 
-		if !bav.ThroughOrigin {
-			b := y1 - a*x1*x1
-			bs[i] = b
-			sumB += b
-		}
-		g := func(ix, ia, ib float64) float64 { return ia*ix*ix + ib }
-		gs[i] = g
+// noise returns a draw of the noise added to the target function.
+func (bav *BiasAndVariance) noise() float64 {
+	if bav.NoiseFunction != nil {
+		return bav.NoiseFunction()
 	}
+	if bav.Sigma == 0 {
+		return 0
+	}
+	if bav.Rand != nil {
+		return bav.Sigma * bav.Rand.NormFloat64()
+	}
+	return bav.Sigma * rand.NormFloat64()
+}
 
-	// this should definitely not be called slope but First Coefficient or something similar.
-	bav.Slope = sumA / float64(bav.Runs)
+// noiseDrawsPerRun is the number of draws of NoiseFunction per run used to estimate σ².
+const noiseDrawsPerRun = 100
 
-	if !bav.ThroughOrigin {
-		bav.Constant = sumB / float64(bav.Runs)
+// noiseVariance returns σ²: Sigma^2 for gaussian noise, or E[ε²] estimated on
+// noiseDrawsPerRun draws per run when the noise is given by NoiseFunction.
+func (bav *BiasAndVariance) noiseVariance() float64 {
+	if bav.NoiseFunction == nil {
+		return bav.Sigma * bav.Sigma
 	}
-
-	gBar := func(x float64) float64 {
-		return x*x*bav.Slope + bav.Constant
+	draws := noiseDrawsPerRun * bav.Runs
+	sum := float64(0)
+	for i := 0; i < draws; i++ {
+		e := bav.NoiseFunction()
+		sum += e * e
 	}
+	return sum / float64(draws)
+}
 
-	bav.Bias = bav.ComputeBias(gBar)
-	bav.Variance = bav.ComputeVariance(gBar, gs, as, bs)
+// measureEout returns the squared error of hypothesis gs on TestExampleSize
+// held-out noisy points each, averaged over the hypothesis.
+func (bav *BiasAndVariance) measureEout(gs []GFunc) float64 {
+	if bav.TestExampleSize <= 0 {
+		return 0
+	}
+	sum := float64(0)
+	for _, g := range gs {
+		for i := 0; i < bav.TestExampleSize; i++ {
+			x := bav.randFloat()
+			y := bav.TargetFunction(x) + bav.noise()
+			sum += math.Pow(g(x)-y, float64(2))
+		}
+	}
+	return sum / float64(len(gs)*bav.TestExampleSize)
 }
 
+// ComputeBias returns Ex[(gBar(x) - f(x))^2].
 func (bav *BiasAndVariance) ComputeBias(gBar func(x float64) float64) float64 {
-	sumBias := float64(0)
-	for i := 0; i < bav.Runs; i++ {
-		x := bav.Interval.RandFloat()
-		sumBias += math.Pow(gBar(x)-bav.TargetFunction(x), float64(2))
-	}
-	return sumBias / float64(bav.Runs)
+	return bav.expectation(func(x float64) float64 {
+		return math.Pow(gBar(x)-bav.TargetFunction(x), float64(2))
+	})
 }
 
-func (bav *BiasAndVariance) ComputeVariance(gBar func(x float64) float64, gs []func(x, a, b float64) float64, as []float64, bs []float64) float64 {
+// ComputeVariance returns Ed[Ex[(g_d(x) - gBar(x))^2]] where the expectation over data sets
+// is the average over the hypothesis gs learned on each data set.
+func (bav *BiasAndVariance) ComputeVariance(gBar func(x float64) float64, gs []GFunc) float64 {
 	sumVar := float64(0)
-	for i := 0; i < bav.Runs; i++ {
-		for j := 0; j < bav.Runs; j++ {
-			x := bav.Interval.RandFloat()
-			sumVar += math.Pow(gs[j](x, as[j], bs[j])-gBar(x), float64(2))
-		}
+	for _, g := range gs {
+		sumVar += bav.expectation(func(x float64) float64 {
+			return math.Pow(g(x)-gBar(x), float64(2))
+		})
 	}
-	return sumVar / math.Pow(float64(bav.Runs), float64(2))
+	return sumVar / float64(len(gs))
+}
 
+// randFloat returns a random float number in the Interval, drawn from Rand when it is set.
+func (bav *BiasAndVariance) randFloat() float64 {
+	if bav.Rand == nil {
+		return bav.Interval.RandFloat()
+	}
+	a, b := float64(bav.Interval.Min), float64(bav.Interval.Max)
+	return a + bav.Rand.Float64()*(b-a)
 }
 
 func (bav *BiasAndVariance) Print() {
 	fmt.Printf("bias = %3.2f\n", bav.Bias)
 	fmt.Printf("variance = %3.2f\n", bav.Variance)
-	fmt.Printf("Eout = %3.2f\n", bav.Bias+bav.Variance)
+	if bav.Noise != 0 {
+		fmt.Printf("noise = %3.2f\n", bav.Noise)
+	}
+	fmt.Printf("Eout = %3.2f\n", bav.Bias+bav.Variance+bav.Noise)
+	if bav.TestExampleSize > 0 {
+		fmt.Printf("measured Eout = %3.2f\n", bav.Eout)
+	}
 }
@@ -0,0 +1,97 @@
+package biasAndVariance
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestDecomposition(t *testing.T) {
+	bav := NewBiasAndVariance()
+	bav.Rand = rand.New(rand.NewSource(1))
+	bav.Quadrature = 20
+	bav.Runs = 2000
+	bav.Sigma = 0.5
+	bav.ThroughOrigin = false
+	// with only 2 points the variance of h(x) = ax + b is heavy tailed.
+	bav.TrainingExampleSize = 10
+	if err := bav.LearnLine(); err != nil {
+		t.Fatalf("LearnLine returned error %v", err)
+	}
+	d := bav.Decomposition()
+	if d.Noise != 0.25 {
+		t.Errorf("Noise == %v, want σ² = 0.25", d.Noise)
+	}
+	// bias + variance + σ² is the expected Eout.
+	if math.Abs(d.Expected-d.Measured) > 0.05*d.Expected {
+		t.Errorf("bias + variance + σ² == %v, want close to the measured Eout %v", d.Expected, d.Measured)
+	}
+}
+
+func TestNoiseFunction(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bav := NewBiasAndVariance()
+	bav.Rand = rand.New(rand.NewSource(2))
+	bav.Quadrature = 20
+	// uniform noise in [-1 : 1] has variance 1/3.
+	bav.NoiseFunction = func() float64 { return 2*r.Float64() - 1 }
+	if err := bav.LearnLine(); err != nil {
+		t.Fatalf("LearnLine returned error %v", err)
+	}
+	d := bav.Decomposition()
+	if math.Abs(d.Noise-float64(1)/3) > 0.01 {
+		t.Errorf("Noise == %v, want close to 1/3", d.Noise)
+	}
+	if math.Abs(d.Expected-d.Measured) > 0.05*d.Expected {
+		t.Errorf("bias + variance + σ² == %v, want close to the measured Eout %v", d.Expected, d.Measured)
+	}
+}
+
+func TestLearnLineCourseValues(t *testing.T) {
+	// f(x) = sin(πx) learned with h(x) = ax on 2 points: gBar(x) ≈ 1.43x, bias ≈ 0.27 and variance ≈ 0.24.
+	bav := NewBiasAndVariance()
+	bav.Rand = rand.New(rand.NewSource(1))
+	bav.Quadrature = 20
+	bav.Runs = 10000
+	if err := bav.LearnLine(); err != nil {
+		t.Fatalf("LearnLine returned error %v", err)
+	}
+	if math.Abs(bav.Slope-1.43) > 0.02 || bav.Constant != 0 {
+		t.Errorf("gBar(x) == %vx + %v, want close to 1.43x", bav.Slope, bav.Constant)
+	}
+	if math.Abs(bav.Bias-0.27) > 0.01 {
+		t.Errorf("Bias == %v, want close to 0.27", bav.Bias)
+	}
+	if math.Abs(bav.Variance-0.24) > 0.02 {
+		t.Errorf("Variance == %v, want close to 0.24", bav.Variance)
+	}
+}
+
+func TestLearnLineNegativeSlope(t *testing.T) {
+	tests := []struct {
+		throughOrigin bool
+		slope, b      float64
+	}{
+		{true, -2, 0},
+		{false, -2, 1},
+	}
+	for _, tt := range tests {
+		bav := NewBiasAndVariance()
+		bav.Rand = rand.New(rand.NewSource(1))
+		bav.Quadrature = 20
+		bav.Runs = 10
+		bav.ThroughOrigin = tt.throughOrigin
+		slope, b := tt.slope, tt.b
+		bav.TargetFunction = func(x float64) float64 { return slope*x + b }
+		if err := bav.LearnLine(); err != nil {
+			t.Fatalf("LearnLine returned error %v", err)
+		}
+		// a line is learned exactly from noiseless points.
+		if math.Abs(bav.Slope-slope) > 1e-9 || math.Abs(bav.Constant-b) > 1e-9 {
+			t.Errorf("gBar(x) == %vx + %v, want %vx + %v", bav.Slope, bav.Constant, slope, b)
+		}
+		if bav.Bias > 1e-12 || bav.Variance > 1e-12 {
+			t.Errorf("bias == %v and variance == %v, want 0", bav.Bias, bav.Variance)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package biasAndVariance
+
+import (
+	"math"
+)
+
+// gaussLegendre returns the n nodes and weights of the Gauss-Legendre quadrature on [-1 : 1]:
+// Integral(f) ~ Sum(weights[i] * f(nodes[i]))
+// The nodes are the roots of the Legendre polynomial P_n found by Newton's method.
+func gaussLegendre(n int) ([]float64, []float64) {
+	nodes := make([]float64, n)
+	weights := make([]float64, n)
+	for i := 0; i < (n+1)/2; i++ {
+		// initial guess of the i-th root.
+		x := math.Cos(math.Pi * (float64(i) + 0.75) / (float64(n) + 0.5))
+		var dp float64
+		for iter := 0; iter < 100; iter++ {
+			// evaluate P_n(x) and its derivative with the recurrence
+			// (k+1)P_{k+1} = (2k+1)xP_k - kP_{k-1}
+			p0, p1 := float64(1), x
+			for k := 1; k < n; k++ {
+				p0, p1 = p1, ((float64(2*k)+1)*x*p1-float64(k)*p0)/float64(k+1)
+			}
+			dp = float64(n) * (x*p1 - p0) / (x*x - 1)
+			dx := p1 / dp
+			x -= dx
+			if math.Abs(dx) < 1e-15 {
+				break
+			}
+		}
+		nodes[i], nodes[n-1-i] = -x, x
+		w := float64(2) / ((float64(1) - x*x) * dp * dp)
+		weights[i], weights[n-1-i] = w, w
+	}
+	return nodes, weights
+}
+
+// expectation returns Ex[f(x)] for x uniform in the Interval.
+// It uses Gauss-Legendre quadrature when Quadrature is positive and Runs random draws otherwise.
+func (bav *BiasAndVariance) expectation(f func(x float64) float64) float64 {
+	if bav.Quadrature <= 0 {
+		sum := float64(0)
+		for i := 0; i < bav.Runs; i++ {
+			sum += f(bav.randFloat())
+		}
+		return sum / float64(bav.Runs)
+	}
+	if len(bav.nodes) != bav.Quadrature {
+		bav.nodes, bav.weights = gaussLegendre(bav.Quadrature)
+	}
+	a, b := float64(bav.Interval.Min), float64(bav.Interval.Max)
+	mid, half := (a+b)/float64(2), (b-a)/float64(2)
+	sum := float64(0)
+	for i, t := range bav.nodes {
+		sum += bav.weights[i] * f(mid+half*t)
+	}
+	// the density of x is 1/(b - a) = 1/(2 half).
+	return sum / float64(2)
+}
@@ -0,0 +1,38 @@
+package biasAndVariance
+
+import (
+	"math"
+	"testing"
+
+	"github.com/santiaago/ml/linear"
+)
+
+func TestGaussLegendre(t *testing.T) {
+	for n := 1; n <= 10; n++ {
+		nodes, weights := gaussLegendre(n)
+		// n nodes integrate x^k exactly on [-1 : 1] for every k up to 2n - 1.
+		for k := 0; k <= 2*n-1; k++ {
+			sum := float64(0)
+			for i, x := range nodes {
+				sum += weights[i] * math.Pow(x, float64(k))
+			}
+			want := float64(0)
+			if k%2 == 0 {
+				want = float64(2) / float64(k+1)
+			}
+			if math.Abs(sum-want) > 1e-12 {
+				t.Errorf("%d nodes: integral of x^%d == %v, want %v", n, k, sum, want)
+			}
+		}
+	}
+}
+
+func TestExpectationQuadrature(t *testing.T) {
+	bav := NewBiasAndVariance()
+	bav.Interval = linear.NewInterval(float64(0), float64(2))
+	bav.Quadrature = 3
+	// Ex[x^3 - x] for x uniform in [0 : 2] is 2 - 1.
+	if got := bav.expectation(func(x float64) float64 { return x*x*x - x }); math.Abs(got-1) > 1e-12 {
+		t.Errorf("expectation == %v, want 1", got)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/santiaago/caltechx.go/generalizationError"
+)
+
+// boundSweep prints a table of all the generalization bounds for a range of sample sizes N,
+// and optionally for several VC dimentions and deltas:
+//
+//	boundSweep -nmin 5 -nmax 10000 -count 20 -log -dvc 10,50 -delta 0.05 -format markdown
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run parses the command line arguments args and writes the table of bounds to w.
+func run(args []string, w io.Writer) error {
+	flags := flag.NewFlagSet("boundSweep", flag.ContinueOnError)
+	nMin := flags.Int("nmin", 10, "smallest sample size")
+	nMax := flags.Int("nmax", 10000, "largest sample size")
+	count := flags.Int("count", 10, "number of sample sizes between nmin and nmax")
+	logScale := flags.Bool("log", false, "space the sample sizes logarithmically")
+	dvcs := flags.String("dvc", "50", "comma separated list of VC dimentions")
+	deltas := flags.String("delta", "0.05", "comma separated list of deltas")
+	format := flags.String("format", "csv", "output format: csv, json or markdown")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var ns []int
+	if *logScale {
+		var err error
+		if ns, err = generalizationError.LogRange(*nMin, *nMax, *count); err != nil {
+			return err
+		}
+	} else {
+		ns = generalizationError.LinearRange(*nMin, *nMax, *count)
+	}
+
+	var dvcValues []int
+	for _, s := range strings.Split(*dvcs, ",") {
+		dvc, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil {
+			return fmt.Errorf("unable to parse dvc %q: %v", s, err)
+		}
+		dvcValues = append(dvcValues, dvc)
+	}
+	var deltaValues []float64
+	for _, s := range strings.Split(*deltas, ",") {
+		delta, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse delta %q: %v", s, err)
+		}
+		deltaValues = append(deltaValues, delta)
+	}
+
+	var genErr generalizationError.GeneralizationError
+	rows, err := genErr.Sweep(ns, dvcValues, deltaValues)
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "csv":
+		return generalizationError.WriteSweepCSV(w, rows)
+	case "json":
+		return generalizationError.WriteSweepJSON(w, rows)
+	case "markdown":
+		return generalizationError.WriteSweepMarkdown(w, rows)
+	}
+	return fmt.Errorf("unknown format %q", *format)
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	var buf bytes.Buffer
+	if err := run([]string{"-nmin", "10", "-nmax", "1000", "-count", "3", "-log", "-dvc", "10,50"}, &buf); err != nil {
+		t.Fatalf("run returned error %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1+3*2 {
+		t.Errorf("run wrote %d lines, want a header and 6 rows:\n%s", len(lines), buf.String())
+	}
+	if !strings.HasPrefix(lines[1], "10,10,0.05,") || !strings.HasPrefix(lines[6], "1000,50,0.05,") {
+		t.Errorf("unexpected rows:\n%s", buf.String())
+	}
+
+	for _, args := range [][]string{
+		{"-log", "-nmin", "0"},
+		{"-dvc", "ten"},
+		{"-format", "xml"},
+	} {
+		if err := run(args, &buf); err == nil {
+			t.Errorf("run(%v) should return an error", args)
+		}
+	}
+}
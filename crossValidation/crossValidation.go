@@ -0,0 +1,195 @@
+package crossValidation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/santiaago/caltechx.go/model"
+)
+
+// Method is the way a dataset is split into folds.
+type Method int
+
+const (
+	KFold           Method = iota // K folds of (almost) the same size.
+	StratifiedKFold               // K folds keeping the proportion of each label of the dataset.
+	LeaveOneOut                   // one fold per example, K is ignored.
+)
+
+// Methods lists all the cross validation methods.
+var Methods = []Method{KFold, StratifiedKFold, LeaveOneOut}
+
+func (m Method) String() string {
+	switch m {
+	case KFold:
+		return "K-fold"
+	case StratifiedKFold:
+		return "stratified K-fold"
+	case LeaveOneOut:
+		return "leave one out"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// CrossValidation holds all the information needed to cross validate a learner.
+type CrossValidation struct {
+	Method  Method     // how the dataset is split into folds.
+	K       int        // number of folds for K-fold and stratified K-fold.
+	Shuffle bool       // shuffle the examples before splitting them into folds.
+	Rand    *rand.Rand // random source used to shuffle.
+}
+
+// NewCrossValidation is a constructor of a basic cross validation:
+// Method = KFold
+// K = 10
+// Shuffle = true
+func NewCrossValidation() *CrossValidation {
+	cv := CrossValidation{}
+	cv.Method = KFold
+	cv.K = 10
+	cv.Shuffle = true
+	cv.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &cv
+}
+
+// Fold holds the indexes of the examples of a fold and the errors of the learner fitted on it.
+type Fold struct {
+	Train      []int   // indexes of the examples the learner is fitted on.
+	Validation []int   // indexes of the examples left out.
+	Ein        float64 // error on the training examples.
+	EVal       float64 // error on the validation examples.
+}
+
+// Result holds the folds of a cross validation and the statistics of their validation errors.
+type Result struct {
+	Folds  []Fold
+	Ein    float64 // mean of the in sample errors of the folds.
+	ECV    float64 // cross validation error: mean of the validation errors of the folds.
+	StdDev float64 // sample standard deviation of the validation errors of the folds.
+}
+
+// Split returns the folds of the dataset d, without errors.
+func (cv *CrossValidation) Split(d model.Dataset) ([]Fold, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	n := d.Len()
+	k := cv.K
+	if cv.Method == LeaveOneOut {
+		k = n
+	}
+	if k < 2 || k > n {
+		return nil, fmt.Errorf("number of folds should be between 2 and %d, got %d", n, k)
+	}
+	var validations [][]int
+	switch cv.Method {
+	case KFold, LeaveOneOut:
+		validations = deal([][]int{cv.indexes(n)}, k)
+	case StratifiedKFold:
+		byLabel := make(map[int][]int)
+		var labels []int
+		for _, i := range cv.indexes(n) {
+			if _, ok := byLabel[d.Y[i]]; !ok {
+				labels = append(labels, d.Y[i])
+			}
+			byLabel[d.Y[i]] = append(byLabel[d.Y[i]], i)
+		}
+		groups := make([][]int, len(labels))
+		for i, label := range labels {
+			groups[i] = byLabel[label]
+		}
+		validations = deal(groups, k)
+	default:
+		return nil, errors.New("unknown cross validation method")
+	}
+	folds := make([]Fold, k)
+	for f, validation := range validations {
+		left := make([]bool, n)
+		for _, i := range validation {
+			left[i] = true
+		}
+		folds[f].Validation = validation
+		for i := 0; i < n; i++ {
+			if !left[i] {
+				folds[f].Train = append(folds[f].Train, i)
+			}
+		}
+	}
+	return folds, nil
+}
+
+// indexes returns the indexes of n examples, shuffled if Shuffle is set.
+func (cv *CrossValidation) indexes(n int) []int {
+	indexes := make([]int, n)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	if cv.Shuffle {
+		r := cv.Rand
+		if r == nil {
+			r = rand.New(rand.NewSource(time.Now().UnixNano()))
+		}
+		r.Shuffle(n, func(i, j int) { indexes[i], indexes[j] = indexes[j], indexes[i] })
+	}
+	return indexes
+}
+
+// deal distributes the indexes of every group in turn to k folds, like cards.
+func deal(groups [][]int, k int) [][]int {
+	folds := make([][]int, k)
+	f := 0
+	for _, group := range groups {
+		for _, i := range group {
+			folds[f] = append(folds[f], i)
+			f = (f + 1) % k
+		}
+	}
+	return folds
+}
+
+// Run fits a learner made by newLearner on the training examples of each fold
+// and scores it on the training and the validation examples.
+func (cv *CrossValidation) Run(newLearner func() model.Learner, d model.Dataset) (*Result, error) {
+	folds, err := cv.Split(d)
+	if err != nil {
+		return nil, err
+	}
+	return Evaluate(newLearner, d, folds)
+}
+
+// Evaluate fits a learner made by newLearner on the training examples of each of the given folds
+// and scores it on the training and the validation examples.
+// Reusing the folds of a single Split compares learners on the same partitions of d.
+func Evaluate(newLearner func() model.Learner, d model.Dataset, splits []Fold) (*Result, error) {
+	if len(splits) < 2 {
+		return nil, errors.New("cross validation needs at least 2 folds")
+	}
+	folds := make([]Fold, len(splits))
+	for f := range splits {
+		folds[f] = Fold{Train: splits[f].Train, Validation: splits[f].Validation}
+	}
+	for f := range folds {
+		train, validation := d.Subset(folds[f].Train), d.Subset(folds[f].Validation)
+		l := newLearner()
+		if err := l.Fit(train); err != nil {
+			return nil, fmt.Errorf("fold %d: %v", f, err)
+		}
+		folds[f].Ein = l.Score(train)
+		folds[f].EVal = l.Score(validation)
+	}
+	res := Result{Folds: folds}
+	for _, fold := range folds {
+		res.Ein += fold.Ein
+		res.ECV += fold.EVal
+	}
+	res.Ein /= float64(len(folds))
+	res.ECV /= float64(len(folds))
+	for _, fold := range folds {
+		res.StdDev += (fold.EVal - res.ECV) * (fold.EVal - res.ECV)
+	}
+	res.StdDev = math.Sqrt(res.StdDev / float64(len(folds)-1))
+	return &res, nil
+}
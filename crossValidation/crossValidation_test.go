@@ -0,0 +1,200 @@
+package crossValidation
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/santiaago/caltechx.go/model"
+)
+
+func TestStratifiedSplit(t *testing.T) {
+	// 20 examples, 5 labeled +1.
+	d := model.Dataset{}
+	for i := 0; i < 20; i++ {
+		y := -1
+		if i%4 == 0 {
+			y = 1
+		}
+		d.X = append(d.X, []float64{float64(i)})
+		d.Y = append(d.Y, y)
+	}
+	cv := NewCrossValidation()
+	cv.Method = StratifiedKFold
+	cv.K = 5
+	folds, err := cv.Split(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[int]int)
+	for f, fold := range folds {
+		if len(fold.Validation) != 4 || len(fold.Train) != 16 {
+			t.Errorf("fold %d has %d validation and %d training examples, want 4 and 16", f, len(fold.Validation), len(fold.Train))
+		}
+		positives := 0
+		for _, i := range fold.Validation {
+			seen[i]++
+			if d.Y[i] > 0 {
+				positives++
+			}
+		}
+		if positives != 1 {
+			t.Errorf("fold %d has %d positive examples, want 1", f, positives)
+		}
+	}
+	if len(seen) != 20 {
+		t.Errorf("folds cover %d examples, want 20", len(seen))
+	}
+	for i, count := range seen {
+		if count != 1 {
+			t.Errorf("example %d is in %d validation sets, want 1", i, count)
+		}
+	}
+}
+
+// majority is a learner that predicts the most frequent label of its training examples, +1 on ties.
+type majority struct {
+	label float64
+}
+
+func (m *majority) Fit(d model.Dataset) error {
+	sum := 0
+	for _, y := range d.Y {
+		sum += y
+	}
+	m.label = 1
+	if sum < 0 {
+		m.label = -1
+	}
+	return nil
+}
+
+func (m *majority) Predict(x []float64) float64   { return m.label }
+func (m *majority) Score(d model.Dataset) float64 { return model.ClassificationError(m, d) }
+func (m *majority) Weights() []float64            { return nil }
+
+func newMajority() model.Learner { return &majority{} }
+
+// labels returns a dataset with one example x = i per label.
+func labels(y ...int) model.Dataset {
+	d := model.Dataset{Y: y}
+	for i := range y {
+		d.X = append(d.X, []float64{float64(i)})
+	}
+	return d
+}
+
+func TestEvaluate(t *testing.T) {
+	d := labels(1, 1, 1, -1, -1, 1)
+	folds := []Fold{
+		{Train: []int{2, 3, 4, 5}, Validation: []int{0, 1}},
+		{Train: []int{0, 1, 4, 5}, Validation: []int{2, 3}},
+		{Train: []int{0, 1, 2, 3}, Validation: []int{4, 5}},
+	}
+	res, err := Evaluate(newMajority, d, folds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the learners predict +1 on every fold, the first one on a tie.
+	want := []struct{ ein, eval float64 }{{0.5, 0}, {0.25, 0.5}, {0.25, 0.5}}
+	for f, fold := range res.Folds {
+		if fold.Ein != want[f].ein || fold.EVal != want[f].eval {
+			t.Errorf("fold %d has Ein = %v and EVal = %v, want %v and %v", f, fold.Ein, fold.EVal, want[f].ein, want[f].eval)
+		}
+	}
+	checkResult(t, res)
+	if _, err := Evaluate(newMajority, d, folds[:1]); err == nil {
+		t.Error("Evaluate() with a single fold should return an error")
+	}
+}
+
+// checkResult checks the statistics of a cross validation with EVal = 0, 0.5, 0.5 and Ein = 0.5, 0.25, 0.25 in any order.
+func checkResult(t *testing.T, res *Result) {
+	if math.Abs(res.Ein-float64(1)/3) > 1e-12 {
+		t.Errorf("Ein = %v, want 1/3", res.Ein)
+	}
+	if math.Abs(res.ECV-float64(1)/3) > 1e-12 {
+		t.Errorf("ECV = %v, want 1/3", res.ECV)
+	}
+	// squared deviations 1/9, 1/36 and 1/36 over 3 - 1 folds.
+	if want := math.Sqrt(float64(1) / 12); math.Abs(res.StdDev-want) > 1e-12 {
+		t.Errorf("StdDev = %v, want %v", res.StdDev, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	d := labels(1, 1, 1, -1, -1, 1)
+	cv := NewCrossValidation()
+	cv.K = 3
+	cv.Shuffle = false
+	res, err := cv.Run(newMajority, d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// without shuffling the examples are dealt to the folds {0, 3}, {1, 4} and {2, 5}.
+	for f, fold := range res.Folds {
+		if !reflect.DeepEqual(fold.Validation, []int{f, f + 3}) {
+			t.Errorf("fold %d validates on %v, want %v", f, fold.Validation, []int{f, f + 3})
+		}
+	}
+	checkResult(t, res)
+}
+
+func TestKFoldSplit(t *testing.T) {
+	cv := NewCrossValidation()
+	cv.K = 3
+	folds, err := cv.Split(labels(1, -1, 1, -1, 1, -1, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[int]bool)
+	for f, fold := range folds {
+		if want := []int{3, 2, 2}[f]; len(fold.Validation) != want || len(fold.Train) != 7-want {
+			t.Errorf("fold %d has %d validation and %d training examples, want %d and %d", f, len(fold.Validation), len(fold.Train), want, 7-want)
+		}
+		for _, i := range fold.Validation {
+			seen[i] = true
+		}
+	}
+	if len(seen) != 7 {
+		t.Errorf("folds cover %d examples, want 7", len(seen))
+	}
+}
+
+func TestLeaveOneOutSplit(t *testing.T) {
+	cv := NewCrossValidation()
+	cv.Method = LeaveOneOut
+	folds, err := cv.Split(labels(1, -1, 1, 1, -1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(folds) != 5 {
+		t.Fatalf("leave one out made %d folds, want 5", len(folds))
+	}
+	seen := make(map[int]bool)
+	for f, fold := range folds {
+		if len(fold.Validation) != 1 || len(fold.Train) != 4 {
+			t.Errorf("fold %d has %d validation and %d training examples, want 1 and 4", f, len(fold.Validation), len(fold.Train))
+			continue
+		}
+		seen[fold.Validation[0]] = true
+	}
+	if len(seen) != 5 {
+		t.Errorf("folds leave out %d examples, want 5", len(seen))
+	}
+}
+
+func TestSplitErrors(t *testing.T) {
+	d := labels(1, -1, 1)
+	cv := NewCrossValidation()
+	for _, k := range []int{0, 1, 4} {
+		cv.K = k
+		if _, err := cv.Split(d); err == nil {
+			t.Errorf("Split() with K = %d and 3 examples should return an error", k)
+		}
+	}
+	cv.Method = LeaveOneOut
+	if _, err := cv.Split(labels(1)); err == nil {
+		t.Error("Split() leaving one out of a single example should return an error")
+	}
+}
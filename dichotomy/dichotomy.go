@@ -0,0 +1,352 @@
+package dichotomy
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/santiaago/caltechx.go/linear"
+)
+
+// HypothesisSet is a set of hypotheses h: R^d -> {-1, +1} whose dichotomies can be counted.
+// Realizable tells if some hypothesis of the set implements the labels y on points x.
+// RandomDichotomy returns the labels of points x given by a random hypothesis of the set.
+type HypothesisSet interface {
+	Realizable(x [][]float64, y []int) bool
+	RandomDichotomy(x [][]float64, r *rand.Rand) []int
+}
+
+// PointGenerator returns n random points.
+type PointGenerator func(n int, r *rand.Rand) [][]float64
+
+// UniformPoints returns a PointGenerator of points uniformly chosen in [min : max]^d.
+func UniformPoints(d int, min, max float64) PointGenerator {
+	return func(n int, r *rand.Rand) [][]float64 {
+		x := make([][]float64, n)
+		for i := range x {
+			x[i] = make([]float64, d)
+			for j := range x[i] {
+				x[i][j] = min + r.Float64()*(max-min)
+			}
+		}
+		return x
+	}
+}
+
+// Method is the way dichotomies are enumerated.
+type Method int
+
+const (
+	Exhaustive Method = iota // every one of the 2^N dichotomies is checked with Realizable.
+	Randomized               // dichotomies are collected from random hypotheses with RandomDichotomy.
+)
+
+// Counter holds all the information needed to estimate the growth function of a hypothesis set.
+// m_H(N) is estimated as the most dichotomies found on any of the PointSets sets of N points drawn by Generator.
+// The estimate is a lower bound of m_H(N): the exhaustive method is exact for each set of points,
+// the randomized method might miss dichotomies.
+type Counter struct {
+	H         HypothesisSet  // hypothesis set to count dichotomies of.
+	Generator PointGenerator // generator of the sets of points.
+	Method    Method         // enumeration method.
+	PointSets int            // number of sets of points tried for each N.
+	Samples   int            // number of random hypotheses drawn for each set of points with the randomized method.
+	Rand      *rand.Rand     // random source of the points and hypotheses, a time seeded source is used when nil.
+}
+
+// NewCounter is a constructor of a basic counter:
+// Method = Exhaustive
+// PointSets = 100
+// Samples = 10000
+func NewCounter(h HypothesisSet, generator PointGenerator) *Counter {
+	c := Counter{}
+	c.H = h
+	c.Generator = generator
+	c.Method = Exhaustive
+	c.PointSets = 100
+	c.Samples = 10000
+	c.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	return &c
+}
+
+// random returns the random source of the counter, creating a time seeded one if Rand is nil.
+func (c *Counter) random() *rand.Rand {
+	if c.Rand == nil {
+		c.Rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return c.Rand
+}
+
+// Count returns the number of distinct dichotomies found on points x.
+func (c *Counter) Count(x [][]float64) int {
+	n := len(x)
+	if c.Method == Randomized {
+		found := make(map[uint64]bool)
+		for i := 0; i < c.Samples; i++ {
+			found[encode(c.H.RandomDichotomy(x, c.random()))] = true
+		}
+		return len(found)
+	}
+	count := 0
+	y := make([]int, n)
+	for code := uint64(0); code < uint64(1)<<uint(n); code++ {
+		for i := range y {
+			y[i] = -1
+			if code&(uint64(1)<<uint(i)) != 0 {
+				y[i] = 1
+			}
+		}
+		if c.H.Realizable(x, y) {
+			count++
+		}
+	}
+	return count
+}
+
+// M_H returns the estimation of the growth function on n points:
+// the most dichotomies found on PointSets sets of n points.
+func (c *Counter) M_H(n int) int {
+	max := 0
+	for i := 0; i < c.PointSets; i++ {
+		if count := c.Count(c.Generator(n, c.random())); count > max {
+			max = count
+		}
+		if max == 1<<uint(n) {
+			break
+		}
+	}
+	return max
+}
+
+// Estimate holds the estimated growth function of a hypothesis set for N in [1 : len(M_H)].
+type Estimate struct {
+	M_H        []int // M_H[i] is the estimated m_H(i+1).
+	BreakPoint int   // smallest N with m_H(N) < 2^N observed, 0 if none was observed.
+	Dvc        int   // estimated VC dimention: BreakPoint - 1.
+}
+
+// maxExhaustivePoints is the largest number of points on which the exhaustive method
+// checks all the 2^N dichotomies.
+const maxExhaustivePoints = 20
+
+// Estimate computes m_H(N) for N in [1 : maxN] and stops at the first break point observed.
+// It returns an error if no break point is observed up to maxN.
+func (c *Counter) Estimate(maxN int) (Estimate, error) {
+	if maxN > 62 {
+		return Estimate{}, errors.New("dichotomies can only be counted on at most 62 points")
+	}
+	if c.Method == Exhaustive && maxN > maxExhaustivePoints {
+		return Estimate{}, errors.New("exhaustive method can only count dichotomies on at most 20 points, use the randomized method")
+	}
+	var e Estimate
+	for n := 1; n <= maxN; n++ {
+		m := c.M_H(n)
+		e.M_H = append(e.M_H, m)
+		if m < 1<<uint(n) {
+			e.BreakPoint = n
+			e.Dvc = n - 1
+			return e, nil
+		}
+	}
+	e.Dvc = maxN
+	return e, errors.New("no break point observed, the VC dimention is at least maxN")
+}
+
+// encode returns the dichotomy y as the bits of an integer.
+func encode(y []int) uint64 {
+	code := uint64(0)
+	for i, yi := range y {
+		if yi > 0 {
+			code |= uint64(1) << uint(i)
+		}
+	}
+	return code
+}
+
+// Perceptron is the hypothesis set h(x) = sign(w0 + w'x) with x in R^D.
+// A dichotomy is realizable if it is linearly separable, which is checked as the feasibility of the
+// linear program: yn(w0 + w'xn) >= 1 for every point n.
+type Perceptron struct{}
+
+func (Perceptron) Realizable(x [][]float64, y []int) bool {
+	a := make([][]float64, len(x))
+	for i := range x {
+		a[i] = make([]float64, len(x[i])+1)
+		a[i][0] = float64(y[i])
+		for j := range x[i] {
+			a[i][j+1] = float64(y[i]) * x[i][j]
+		}
+	}
+	return feasible(a)
+}
+
+func (Perceptron) RandomDichotomy(x [][]float64, r *rand.Rand) []int {
+	w := make([]float64, len(x[0])+1)
+	for i := range w {
+		w[i] = r.NormFloat64()
+	}
+	y := make([]int, len(x))
+	for i := range x {
+		s := w[0]
+		for j := range x[i] {
+			s += w[j+1] * x[i][j]
+		}
+		y[i] = linear.Sign(s)
+	}
+	return y
+}
+
+// PositiveRays is the hypothesis set h(x) = sign(x - a) on the real line.
+// Only the first coordinate of each point is used.
+type PositiveRays struct{}
+
+func (PositiveRays) Realizable(x [][]float64, y []int) bool {
+	// every point labeled -1 should be left of every point labeled +1.
+	maxNeg, minPos := math.Inf(-1), math.Inf(1)
+	for i := range x {
+		if y[i] > 0 {
+			minPos = math.Min(minPos, x[i][0])
+		} else {
+			maxNeg = math.Max(maxNeg, x[i][0])
+		}
+	}
+	return maxNeg < minPos
+}
+
+func (PositiveRays) RandomDichotomy(x [][]float64, r *rand.Rand) []int {
+	a := randThreshold(x, r)
+	y := make([]int, len(x))
+	for i := range x {
+		y[i] = linear.Sign(x[i][0] - a)
+	}
+	return y
+}
+
+// PositiveIntervals is the hypothesis set h(x) = +1 for x in [a : b], -1 otherwise, on the real line.
+// Only the first coordinate of each point is used.
+type PositiveIntervals struct{}
+
+func (PositiveIntervals) Realizable(x [][]float64, y []int) bool {
+	// no point labeled -1 should lie between the points labeled +1.
+	minPos, maxPos := math.Inf(1), math.Inf(-1)
+	for i := range x {
+		if y[i] > 0 {
+			minPos = math.Min(minPos, x[i][0])
+			maxPos = math.Max(maxPos, x[i][0])
+		}
+	}
+	for i := range x {
+		if y[i] < 0 && x[i][0] >= minPos && x[i][0] <= maxPos {
+			return false
+		}
+	}
+	return true
+}
+
+func (PositiveIntervals) RandomDichotomy(x [][]float64, r *rand.Rand) []int {
+	a, b := randThreshold(x, r), randThreshold(x, r)
+	if a > b {
+		a, b = b, a
+	}
+	y := make([]int, len(x))
+	for i := range x {
+		y[i] = -1
+		if x[i][0] >= a && x[i][0] <= b {
+			y[i] = 1
+		}
+	}
+	return y
+}
+
+// randThreshold returns a random value slightly outside of the range of the first coordinate of points x.
+func randThreshold(x [][]float64, r *rand.Rand) float64 {
+	min, max := math.Inf(1), math.Inf(-1)
+	for i := range x {
+		min = math.Min(min, x[i][0])
+		max = math.Max(max, x[i][0])
+	}
+	margin := (max-min)/float64(10) + 1e-9
+	return min - margin + r.Float64()*(max-min+float64(2)*margin)
+}
+
+// feasible tells if there is a vector w such that a w >= 1, with w free.
+// It runs phase one of the simplex method with Bland's rule on the problem:
+// minimize Sum(t) subject to a(p - q) - s + t = 1 with p, q, s, t >= 0
+func feasible(a [][]float64) bool {
+	const eps = 1e-9
+	m, n := len(a), len(a[0])
+	cols := 2*n + 2*m
+	// tableau rows are the constraints, with the right hand side in the last column.
+	tab := make([][]float64, m)
+	basis := make([]int, m)
+	for i := range tab {
+		tab[i] = make([]float64, cols+1)
+		for j := 0; j < n; j++ {
+			tab[i][j] = a[i][j]
+			tab[i][n+j] = -a[i][j]
+		}
+		tab[i][2*n+i] = -1
+		tab[i][2*n+m+i] = 1
+		tab[i][cols] = 1
+		basis[i] = 2*n + m + i
+	}
+	// reduced costs of the phase one objective, with its value negated in the last column.
+	cost := make([]float64, cols+1)
+	for j := 0; j < 2*n+m; j++ {
+		for i := 0; i < m; i++ {
+			cost[j] -= tab[i][j]
+		}
+	}
+	cost[cols] = -float64(m)
+
+	for {
+		enter := -1
+		for j := 0; j < cols; j++ {
+			if cost[j] < -eps {
+				enter = j
+				break
+			}
+		}
+		if enter == -1 {
+			break
+		}
+		leave := -1
+		best := math.Inf(1)
+		for i := 0; i < m; i++ {
+			if tab[i][enter] > eps {
+				ratio := tab[i][cols] / tab[i][enter]
+				if ratio < best-eps || (math.Abs(ratio-best) <= eps && basis[i] < basis[leave]) {
+					best = ratio
+					leave = i
+				}
+			}
+		}
+		if leave == -1 {
+			break
+		}
+		pivot(tab, cost, leave, enter)
+		basis[leave] = enter
+	}
+	return -cost[cols] < 1e-7
+}
+
+// pivot makes column enter a unit vector with a 1 in row leave.
+func pivot(tab [][]float64, cost []float64, leave, enter int) {
+	p := tab[leave][enter]
+	for j := range tab[leave] {
+		tab[leave][j] /= p
+	}
+	for i := range tab {
+		if i != leave && tab[i][enter] != 0 {
+			f := tab[i][enter]
+			for j := range tab[i] {
+				tab[i][j] -= f * tab[leave][j]
+			}
+		}
+	}
+	f := cost[enter]
+	for j := range cost {
+		cost[j] -= f * tab[leave][j]
+	}
+}
@@ -0,0 +1,70 @@
+package dichotomy
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// line returns n points on the real line at 1, 2, ..., n.
+func line(n int) [][]float64 {
+	x := make([][]float64, n)
+	for i := range x {
+		x[i] = []float64{float64(i + 1)}
+	}
+	return x
+}
+
+func TestCountRaysAndIntervals(t *testing.T) {
+	rays := NewCounter(PositiveRays{}, UniformPoints(1, -1, 1))
+	intervals := NewCounter(PositiveIntervals{}, UniformPoints(1, -1, 1))
+	for n := 1; n <= 8; n++ {
+		if got := rays.Count(line(n)); got != n+1 {
+			t.Errorf("positive rays: Count(%d points) == %d, want %d", n, got, n+1)
+		}
+		if got, want := intervals.Count(line(n)), (n+1)*n/2+1; got != want {
+			t.Errorf("positive intervals: Count(%d points) == %d, want %d", n, got, want)
+		}
+	}
+}
+
+func TestCountRandomized(t *testing.T) {
+	c := NewCounter(PositiveRays{}, UniformPoints(1, -1, 1))
+	c.Method = Randomized
+	c.Rand = rand.New(rand.NewSource(1))
+	if got := c.Count(line(5)); got != 6 {
+		t.Errorf("positive rays: randomized Count(5 points) == %d, want 6", got)
+	}
+}
+
+func TestPerceptron(t *testing.T) {
+	var p Perceptron
+	square := [][]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	if p.Realizable(square, []int{1, -1, 1, -1}) {
+		t.Errorf("XOR labels on a square should not be realizable")
+	}
+	if !p.Realizable(square, []int{1, 1, -1, -1}) {
+		t.Errorf("labels split by a line should be realizable")
+	}
+
+	c := NewCounter(p, UniformPoints(2, -1, 1))
+	if got := c.Count(square[:3]); got != 8 {
+		t.Errorf("Count(3 points) == %d, want 8", got)
+	}
+	if got := c.Count(square); got != 14 {
+		t.Errorf("Count(4 points) == %d, want 14", got)
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	c := &Counter{H: PositiveIntervals{}, Generator: UniformPoints(1, -1, 1), PointSets: 10}
+	e, err := c.Estimate(10)
+	if err != nil {
+		t.Fatalf("Estimate returned error %v", err)
+	}
+	if e.BreakPoint != 3 || e.Dvc != 2 {
+		t.Errorf("Estimate == %+v, want break point 3 and dvc 2", e)
+	}
+	if _, err := c.Estimate(21); err == nil {
+		t.Errorf("exhaustive Estimate on 21 points should return an error")
+	}
+}
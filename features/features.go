@@ -0,0 +1,282 @@
+package features
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/santiaago/caltechx.go/linreg"
+)
+
+// Transform is a feature transform z = Φ(x) of the input x = (1, x1, ..., xd), with z0 = 1.
+// Names are computed from the names of the inputs so that they stay meaningful after composition.
+type Transform struct {
+	Func  linreg.TransformFunc           // Φ
+	D     int                            // dimention d of the inputs, without x0.
+	names func(inputs []string) []string // names of z1, ..., zn given the names of x1, ..., xd.
+}
+
+// Dim returns the dimention of the output z, z0 included.
+func (t Transform) Dim() int {
+	return len(t.Names())
+}
+
+// Names returns the names of the features of z, with x1, ..., xd the names of the inputs.
+func (t Transform) Names() []string {
+	inputs := make([]string, t.D)
+	for i := range inputs {
+		inputs[i] = fmt.Sprintf("x%d", i+1)
+	}
+	return append([]string{"1"}, t.names(inputs)...)
+}
+
+// Identity is the transform Φ(x) = x.
+func Identity(d int) Transform {
+	return Transform{
+		Func: func(x []float64) []float64 {
+			return append([]float64{}, x...)
+		},
+		D: d,
+		names: func(inputs []string) []string {
+			return append([]string{}, inputs...)
+		},
+	}
+}
+
+// Polynomial is the transform into every monomial of x1, ..., xd of degree 1 to q, ordered by degree.
+// Without cross terms it only keeps the powers xi^k.
+func Polynomial(d, q int, crossTerms bool) Transform {
+	return product(d, q, crossTerms,
+		func(k int, x float64) float64 { return math.Pow(x, float64(k)) },
+		func(k int, input string) string {
+			if k == 1 {
+				return input
+			}
+			return fmt.Sprintf("%s^%d", input, k)
+		})
+}
+
+// Legendre is the transform into the products of Legendre polynomials Lk(xi) of total degree 1 to q.
+// Without cross terms it only keeps the polynomials Lk(xi).
+// Lk is defined by the recurrence:
+// L0(x) = 1, L1(x) = x, Lk(x) = ((2k - 1)x Lk-1(x) - (k - 1)Lk-2(x)) / k
+func Legendre(d, q int, crossTerms bool) Transform {
+	return product(d, q, crossTerms,
+		LegendrePolynomial,
+		func(k int, input string) string { return fmt.Sprintf("L%d(%s)", k, input) })
+}
+
+// LegendrePolynomial returns Lk(x).
+func LegendrePolynomial(k int, x float64) float64 {
+	previous, current := float64(1), x
+	if k == 0 {
+		return previous
+	}
+	for i := 2; i <= k; i++ {
+		previous, current = current, (float64(2*i-1)*x*current-float64(i-1)*previous)/float64(i)
+	}
+	return current
+}
+
+// product is the transform into Π basis(ei, xi) for every exponent vector e of total degree 1 to q.
+func product(d, q int, crossTerms bool, basis func(k int, x float64) float64, name func(k int, input string) string) Transform {
+	exponents := Exponents(d, q, crossTerms)
+	return Transform{
+		Func: func(x []float64) []float64 {
+			z := make([]float64, len(exponents)+1)
+			z[0] = float64(1)
+			for n, e := range exponents {
+				z[n+1] = float64(1)
+				for i, k := range e {
+					if k > 0 {
+						z[n+1] *= basis(k, x[i+1])
+					}
+				}
+			}
+			return z
+		},
+		D: d,
+		names: func(inputs []string) []string {
+			names := make([]string, len(exponents))
+			for n, e := range exponents {
+				var factors []string
+				for i, k := range e {
+					if k > 0 {
+						factors = append(factors, name(k, inputs[i]))
+					}
+				}
+				names[n] = strings.Join(factors, "*")
+			}
+			return names
+		},
+	}
+}
+
+// Exponents returns the exponent vectors of d variables of total degree 1 to q,
+// ordered by degree and then with the highest powers of the first variables first:
+// for d = 2 and q = 2: (1 0) (0 1) (2 0) (1 1) (0 2)
+// Without cross terms only vectors with a single non zero exponent are kept.
+func Exponents(d, q int, crossTerms bool) [][]int {
+	var all [][]int
+	for degree := 1; degree <= q; degree++ {
+		var rec func(i, left int, e []int)
+		rec = func(i, left int, e []int) {
+			if i == d-1 {
+				e[i] = left
+				all = append(all, append([]int{}, e...))
+				return
+			}
+			for k := left; k >= 0; k-- {
+				e[i] = k
+				rec(i+1, left-k, e)
+			}
+		}
+		if d > 0 {
+			rec(0, degree, make([]int, d))
+		}
+	}
+	if crossTerms {
+		return all
+	}
+	var pure [][]int
+	for _, e := range all {
+		nonZero := 0
+		for _, k := range e {
+			if k > 0 {
+				nonZero++
+			}
+		}
+		if nonZero == 1 {
+			pure = append(pure, e)
+		}
+	}
+	return pure
+}
+
+// Degrees returns the total degree of every feature of Polynomial(d, q, crossTerms)
+// and Legendre(d, q, crossTerms), z0 included with degree 0.
+// It is the order used by linreg.SoftOrderTikhonov.
+func Degrees(d, q int, crossTerms bool) []int {
+	degrees := []int{0}
+	for _, e := range Exponents(d, q, crossTerms) {
+		total := 0
+		for _, k := range e {
+			total += k
+		}
+		degrees = append(degrees, total)
+	}
+	return degrees
+}
+
+// AbsDiff is the transform into the single feature |xi - xj|, with inputs numbered from 1.
+func AbsDiff(d, i, j int) Transform {
+	return pairFeature(d, i, j, func(a, b float64) float64 { return math.Abs(a - b) }, "|%s - %s|")
+}
+
+// AbsSum is the transform into the single feature |xi + xj|, with inputs numbered from 1.
+func AbsSum(d, i, j int) Transform {
+	return pairFeature(d, i, j, func(a, b float64) float64 { return math.Abs(a + b) }, "|%s + %s|")
+}
+
+// Product is the transform into the single feature xi*xj, with inputs numbered from 1.
+func Product(d, i, j int) Transform {
+	return pairFeature(d, i, j, func(a, b float64) float64 { return a * b }, "%s*%s")
+}
+
+func pairFeature(d, i, j int, f func(a, b float64) float64, format string) Transform {
+	return Transform{
+		Func: func(x []float64) []float64 {
+			return []float64{float64(1), f(x[i], x[j])}
+		},
+		D: d,
+		names: func(inputs []string) []string {
+			return []string{fmt.Sprintf(format, inputs[i-1], inputs[j-1])}
+		},
+	}
+}
+
+// Concat is the transform into the features of every transform in ts, with a single z0 = 1.
+// It returns an error if ts is empty or the transforms do not have the same input dimention.
+func Concat(ts ...Transform) (Transform, error) {
+	if len(ts) == 0 {
+		return Transform{}, errors.New("concat needs at least one transform")
+	}
+	for _, t := range ts {
+		if t.D != ts[0].D {
+			return Transform{}, errors.New("concatenated transforms should have the same input dimention")
+		}
+	}
+	return Transform{
+		Func: func(x []float64) []float64 {
+			z := []float64{float64(1)}
+			for _, t := range ts {
+				z = append(z, t.Func(x)[1:]...)
+			}
+			return z
+		},
+		D: ts[0].D,
+		names: func(inputs []string) []string {
+			var names []string
+			for _, t := range ts {
+				names = append(names, t.names(inputs)...)
+			}
+			return names
+		},
+	}, nil
+}
+
+// Compose is the transform Φ(x) = outer(inner(x)).
+// It returns an error if the input dimention of outer is not the output dimention of inner without z0.
+func Compose(outer, inner Transform) (Transform, error) {
+	if outer.D != inner.Dim()-1 {
+		return Transform{}, errors.New("outer transform should take the features of the inner transform as inputs")
+	}
+	return Transform{
+		Func: func(x []float64) []float64 {
+			return outer.Func(inner.Func(x))
+		},
+		D: inner.D,
+		names: func(inputs []string) []string {
+			innerNames := inner.names(inputs)
+			for i, name := range innerNames {
+				if !atomic(name) {
+					innerNames[i] = "(" + name + ")"
+				}
+			}
+			return outer.names(innerNames)
+		},
+	}, nil
+}
+
+// atomic tells if the feature name can be used as a factor without parentheses:
+// it has no operator outside of parentheses and absolute value bars.
+// A bar opens an absolute value at the start of the name or after an operator or an opening,
+// and closes it otherwise.
+func atomic(name string) bool {
+	depth := 0
+	opening := true // a bar at the current position opens an absolute value.
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; {
+		case c == '(':
+			depth++
+			opening = true
+		case c == ')':
+			depth--
+			opening = false
+		case c == '|' && opening:
+			depth++
+		case c == '|':
+			depth--
+			opening = false
+		case strings.IndexByte(" *^+-", c) >= 0:
+			if depth == 0 {
+				return false
+			}
+			opening = true
+		default:
+			opening = false
+		}
+	}
+	return true
+}
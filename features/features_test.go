@@ -0,0 +1,144 @@
+package features
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+// legendreOrder matches the order k of a factor Lk(xi) in the name of a Legendre feature.
+var legendreOrder = regexp.MustCompile(`L(\d+)\(`)
+
+func TestPolynomial(t *testing.T) {
+	p := Polynomial(2, 2, true)
+	if want := []string{"1", "x1", "x2", "x1^2", "x1*x2", "x2^2"}; !reflect.DeepEqual(p.Names(), want) {
+		t.Errorf("Names() == %v, want %v", p.Names(), want)
+	}
+	if z, want := p.Func([]float64{1, 2, 3}), []float64{1, 2, 3, 4, 6, 9}; !reflect.DeepEqual(z, want) {
+		t.Errorf("Func() == %v, want %v", z, want)
+	}
+	pure := Polynomial(2, 3, false)
+	if want := []string{"1", "x1", "x2", "x1^2", "x2^2", "x1^3", "x2^3"}; !reflect.DeepEqual(pure.Names(), want) {
+		t.Errorf("Names() without cross terms == %v, want %v", pure.Names(), want)
+	}
+}
+
+func TestLegendre(t *testing.T) {
+	l := Legendre(1, 3, false)
+	want := []float64{1, 0.5, -0.125, -0.4375}
+	z := l.Func([]float64{1, 0.5})
+	for i := range want {
+		if math.Abs(z[i]-want[i]) > 1e-12 {
+			t.Errorf("Func() == %v, want %v", z, want)
+			break
+		}
+	}
+	if names := l.Names(); names[2] != "L2(x1)" {
+		t.Errorf("Names() == %v, want L2(x1) as third feature", names)
+	}
+}
+
+func TestExponentsAndDegrees(t *testing.T) {
+	want := [][]int{{1, 0}, {0, 1}, {2, 0}, {1, 1}, {0, 2}}
+	if e := Exponents(2, 2, true); !reflect.DeepEqual(e, want) {
+		t.Errorf("Exponents(2, 2, true) == %v, want %v", e, want)
+	}
+	if e := Exponents(3, 2, true); len(e) != 9 {
+		t.Errorf("Exponents(3, 2, true) has %d vectors, want 9", len(e))
+	}
+	for _, cross := range []bool{true, false} {
+		degrees := Degrees(3, 3, cross)
+		for _, tr := range []Transform{Polynomial(3, 3, cross), Legendre(3, 3, cross)} {
+			if len(degrees) != tr.Dim() {
+				t.Errorf("Degrees has %d values for %d features", len(degrees), tr.Dim())
+			}
+		}
+		// the degree of every monomial is the number of factors of its name in Polynomial.
+		z := Polynomial(3, 3, cross).Func([]float64{1, 2, 2, 2})
+		for i, d := range degrees {
+			if z[i] != math.Pow(2, float64(d)) {
+				t.Errorf("feature %d has value %v, want degree %d", i, z[i], d)
+			}
+		}
+		// the degree of every Legendre feature is the sum of the orders k of its factors Lk(xi).
+		for i, name := range Legendre(3, 3, cross).Names() {
+			total := 0
+			for _, m := range legendreOrder.FindAllStringSubmatch(name, -1) {
+				k, _ := strconv.Atoi(m[1])
+				total += k
+			}
+			if total != degrees[i] {
+				t.Errorf("Legendre feature %d is %v, want degree %d", i, name, degrees[i])
+			}
+		}
+	}
+}
+
+func TestConcat(t *testing.T) {
+	c, err := Concat(Identity(2), AbsDiff(2, 1, 2), Product(2, 1, 2))
+	if err != nil {
+		t.Fatalf("Concat returned error %v", err)
+	}
+	if want := []string{"1", "x1", "x2", "|x1 - x2|", "x1*x2"}; !reflect.DeepEqual(c.Names(), want) {
+		t.Errorf("Names() == %v, want %v", c.Names(), want)
+	}
+	if z, want := c.Func([]float64{1, 2, 5}), []float64{1, 2, 5, 3, 10}; !reflect.DeepEqual(z, want) {
+		t.Errorf("Func() == %v, want %v", z, want)
+	}
+	if _, err := Concat(); err == nil {
+		t.Errorf("Concat() should return an error")
+	}
+	if _, err := Concat(Identity(2), Identity(3)); err == nil {
+		t.Errorf("Concat of different input dimentions should return an error")
+	}
+}
+
+func TestComposeNames(t *testing.T) {
+	abs, _ := Concat(AbsDiff(2, 1, 2), AbsSum(2, 1, 2))
+	squares, err := Compose(Polynomial(2, 2, true), abs)
+	if err != nil {
+		t.Fatalf("Compose returned error %v", err)
+	}
+	want := []string{"1", "|x1 - x2|", "|x1 + x2|", "|x1 - x2|^2", "|x1 - x2|*|x1 + x2|", "|x1 + x2|^2"}
+	if !reflect.DeepEqual(squares.Names(), want) {
+		t.Errorf("Names() == %v, want %v", squares.Names(), want)
+	}
+
+	inner, _ := Compose(Product(2, 1, 2), abs)
+	product, err := Compose(Polynomial(1, 2, false), inner)
+	if err != nil {
+		t.Fatalf("Compose returned error %v", err)
+	}
+	want = []string{"1", "(|x1 - x2|*|x1 + x2|)", "(|x1 - x2|*|x1 + x2|)^2"}
+	if !reflect.DeepEqual(product.Names(), want) {
+		t.Errorf("Names() == %v, want %v", product.Names(), want)
+	}
+	if z := product.Func([]float64{1, 3, 1}); z[1] != 8 || z[2] != 64 {
+		t.Errorf("Func() == %v, want [1 8 64]", z)
+	}
+	if _, err := Compose(Polynomial(3, 2, true), abs); err == nil {
+		t.Errorf("Compose of a 3 inputs transform over 2 features should return an error")
+	}
+}
+
+func TestAtomic(t *testing.T) {
+	tests := map[string]bool{
+		"x1":                  true,
+		"L2(x1)":              true,
+		"|x1 - x2|":           true,
+		"||x1| - x2|":         true,
+		"(x1*x2)":             true,
+		"x1^2":                false,
+		"x1*x2":               false,
+		"|x1|*|x2|":           false,
+		"|x1 - x2|*|x1 + x2|": false,
+		"L1(x1)*L1(x2)":       false,
+	}
+	for name, want := range tests {
+		if got := atomic(name); got != want {
+			t.Errorf("atomic(%q) == %v, want %v", name, got, want)
+		}
+	}
+}
@@ -6,103 +6,81 @@ import (
 
 // There are various bounds for generalization error.
 // This structure holds 4 functions for calculating these bounds:
-//  - Original VC bound
-//  - Rademacher Penalty Bound
-//  - Parrondo and Van den Broek
-//  - Devroye
+//   - Original VC bound
+//   - Rademacher Penalty Bound
+//   - Parrondo and Van den Broek
+//   - Devroye
 type GeneralizationError struct {
-	Dvc        int     // VC Dimention.
-	Delta      float64 // Upper bound on the probability that generalization error will be more than a specified value.
-	Confidence float64 // 1 - delta = Confidence that generalization error will be at most a specified value.
-	Epsilon    float64 // Generalization error tolerance.
-	Margin     float64 // Margin of error.
+	Dvc             int            // VC Dimention.
+	Delta           float64        // Upper bound on the probability that generalization error will be more than a specified value.
+	Confidence      float64        // 1 - delta = Confidence that generalization error will be at most a specified value.
+	Epsilon         float64        // Generalization error tolerance.
+	Margin          float64        // Margin of error used as tolerance when solving implicit bounds.
+	IterationLimit  int            // Maximum number of iterations when solving implicit bounds.
+	EvaluationLimit int            // Maximum number of evaluations of a bound when searching its sample complexity.
+	Growth          GrowthFunction // Growth function m_H used by the bounds, PolynomialBound with Dvc when nil.
 }
 
-// M_H is the growth function: counts the most dicotomies on any N points following:
+// M_H is the growth function: counts the most dicotomies on any N points.
+// It uses Growth if it is set, otherwise it uses the PolynomialBound with Dvc:
 // M_H(N) <= 2**N if N <= VC dimention
 // M_H(N) <= N**Dvc if N > VC dimention
 func (g *GeneralizationError) M_H(n int) float64 {
-	if n <= g.Dvc {
-		return math.Pow(float64(2), float64(n))
-	}
-	return math.Pow(float64(n), float64(g.Dvc))
+	return g.growth().M(n)
 }
 
-// M_HLog is the growth function: counts the most dicotomies on any N points following:
+// M_HLog is the log of the growth function: ln(M_H(N)).
+// It uses Growth if it is set, otherwise it uses the PolynomialBound with Dvc:
 // M_HLog(N) <= N*Ln(2) if N <= VC dimention
 // M_HLog(N) <= Dvc*Ln(N) if N > VC dimention
 func (g *GeneralizationError) M_HLog(n int) float64 {
-	if n <= g.Dvc {
-		return float64(n) * math.Log(2)
+	return g.growth().LogM(n)
+}
+
+// growth returns the growth function used by the bounds.
+func (g *GeneralizationError) growth() GrowthFunction {
+	if g.Growth != nil {
+		return g.Growth
 	}
-	return float64(g.Dvc) * math.Log(float64(n))
+	return PolynomialBound{Dvc: g.Dvc}
 }
 
 // VCBound is the original Vapnik Chervonenkis bound
 // epsilon ≤ sqrt(8/N ln 4mH(2N))
 func (g *GeneralizationError) VCBound(n int) float64 {
-	return math.Sqrt(float64(8) * float64(float64(1)/float64(n)) * math.Log(float64(4)*g.M_H(2*n)/g.Delta))
+	return math.Sqrt(float64(8) * float64(float64(1)/float64(n)) * (math.Log(float64(4)) + g.M_HLog(2*n) - math.Log(g.Delta)))
 }
 
 // RademacherPenaltyBound:
 // epsilon ≤ sqrt(2 ln(2NmH(N))/N) + sqrt(2/N ln 1/δ +) + 1/N
 func (g *GeneralizationError) RademacherPenaltyBound(n int) float64 {
-	return math.Sqrt(float64(2)*math.Log(float64(2)*float64(n)*g.M_H(n))/float64(n)) + math.Sqrt(float64(2)/float64(n)*math.Log(float64(1)/float64(g.Delta))) + float64(1)/float64(n)
+	return math.Sqrt(float64(2)*(math.Log(float64(2)*float64(n))+g.M_HLog(n))/float64(n)) + math.Sqrt(float64(2)/float64(n)*math.Log(float64(1)/float64(g.Delta))) + float64(1)/float64(n)
 }
 
 // ParrondoAndVanDenBroek:
 // epsilon ≤ sqrt(1/N(2epsilon + ln 6mH(2N)/δ))
-func (g *GeneralizationError) ParrondoAndVanDenBroek(n int) float64 {
-	f := func(n int, eps float64) float64 {
-		return math.Sqrt((float64(1) / float64(n)) * (float64(2)*eps + math.Log(float64(6)*g.M_H(2*n)/g.Delta)))
+// The bound is implicit in epsilon, it returns the smallest epsilon solving it.
+func (g *GeneralizationError) ParrondoAndVanDenBroek(n int) (float64, error) {
+	f := func(eps float64) float64 {
+		return math.Sqrt((float64(1) / float64(n)) * (float64(2)*eps + math.Log(float64(6)) + g.M_HLog(2*n) - math.Log(g.Delta)))
 	}
-	return f(n, 1)
-	e1 := f(n, 0)
-	for i := 0; i < 10000; i++ {
-		e0 := e1
-		e1 = f(n, e0)
-		// if e1-e0 <= margin {
-		// 	break
-		// }
-	}
-	return e1
+	return g.solveImplicit(f)
 }
 
 // DevroyeLog:
 // epsilon ≤ sqrt(1/2N (4epsilon(1 + epsilon) + ln 4mH(N^2)/δ)
-func (g *GeneralizationError) DevroyeLog(n int) float64 {
-	f := func(n int, eps float64) float64 {
+// It uses the log of the growth function so that it can be computed for large N.
+// The bound is implicit in epsilon, it returns the smallest epsilon solving it.
+func (g *GeneralizationError) DevroyeLog(n int) (float64, error) {
+	f := func(eps float64) float64 {
 		return math.Sqrt((float64(1) / float64(2*n)) * (float64(4)*eps*float64(1+eps) + math.Log(float64(4)) + g.M_HLog(n*n) - math.Log(g.Delta)))
 	}
-	return f(n, 1)
-	e1 := f(n, 0)
-	for i := 0; i < 10000; i++ {
-		e0 := e1
-		e1 = f(n, e0)
-		// if e1-e0 <= margin {
-		// 	break
-		// }
-	}
-	return e1
+	return g.solveImplicit(f)
 }
 
-// Devroye:
-// epsilon ≤ sqrt(1/2N (4epsilon(1 + epsilon) + ln 4mH(N^2)/δ)
-func (g *GeneralizationError) Devroye(n int) float64 {
-	f := func(n int, eps float64) float64 {
-		a := (float64(1) / float64(2*n))
-		return math.Sqrt(a * (float64(4)*eps*float64(1+eps) + (math.Log(float64(4) * g.M_H(n*n) / g.Delta))))
-	}
-	return f(n, 1)
-	e1 := f(n, 0)
-	for i := 0; i < 10000; i++ {
-		e0 := e1
-		e1 = f(n, e0)
-		// if e1-e0 <= margin {
-		// 	break
-		// }
-	}
-	return e1
+// Devroye is DevroyeLog, the growth function is always evaluated in log space.
+func (g *GeneralizationError) Devroye(n int) (float64, error) {
+	return g.DevroyeLog(n)
 }
 
 // SetConfidence will set the confidence variable and it's oposite Delta as 1 - Confidence.
@@ -119,18 +97,8 @@ func (g *GeneralizationError) SetDelta(d float64) {
 
 // LowerBound computes the minimum sample size necessary to satisfy the VC inequality
 // N >= (8/epsilon^2)ln((4*M_H(2*N))/delta)
-// It uses an iterative method for solving N = f(N).
-func (g *GeneralizationError) LowerBound() int {
-	n0 := 1000
-	f := func(n int) int {
-		x := (float64(4) * (math.Pow(float64(2*n), float64(g.Dvc)) + float64(1))) / g.Delta
-		res := float64(8) * float64(1) / (g.Epsilon * g.Epsilon) * math.Log(x)
-		return int(res)
-	}
-	n1 := f(n0)
-	for i := 0; i < 10000; i++ {
-		n0 = n1
-		n1 = f(n0)
-	}
-	return n1
+// It is SampleComplexity for the original VC bound.
+func (g *GeneralizationError) LowerBound() (int, error) {
+	s, err := g.SampleComplexity(BoundVC)
+	return s.N, err
 }
@@ -0,0 +1,101 @@
+package generalizationError
+
+import (
+	"math"
+	"testing"
+)
+
+func TestImplicitBounds(t *testing.T) {
+	var g GeneralizationError
+	g.Dvc = 50
+	g.SetDelta(0.05)
+
+	bounds := map[string]func(int) (float64, error){
+		"ParrondoAndVanDenBroek": g.ParrondoAndVanDenBroek,
+		"Devroye":                g.DevroyeLog,
+	}
+	want := map[string]float64{
+		"ParrondoAndVanDenBroek": 0.2237,
+		"Devroye":                0.2152,
+	}
+	for name, bound := range bounds {
+		eps, err := bound(10000)
+		if err != nil {
+			t.Errorf("%s(10000) returned error %v", name, err)
+			continue
+		}
+		if math.Abs(eps-want[name]) > 1e-4 {
+			t.Errorf("%s(10000) == %v, want %v", name, eps, want[name])
+		}
+	}
+
+	// for N = 1 the right hand side of Devroye grows faster than epsilon.
+	if _, err := g.Devroye(1); err == nil {
+		t.Errorf("Devroye(1) should return an error as the bound has no solution")
+	}
+}
+
+func TestBrent(t *testing.T) {
+	x, _, err := Brent(func(x float64) float64 { return x*x - 2 }, 0, 2, 1e-12, 100)
+	if err != nil || math.Abs(x-math.Sqrt2) > 1e-10 {
+		t.Errorf("Brent(x^2 - 2) == %v, %v, want %v", x, err, math.Sqrt2)
+	}
+	if _, _, err := Brent(func(x float64) float64 { return x*x + 1 }, 0, 2, 1e-12, 100); err == nil {
+		t.Errorf("Brent(x^2 + 1) should return an error as the root is not bracketed")
+	}
+}
+
+func TestGrowthFunctions(t *testing.T) {
+	tests := []struct {
+		name string
+		mh   GrowthFunction
+		n    int
+		want float64
+	}{
+		{"PositiveRays", PositiveRays{}, 5, 6},
+		{"PositiveIntervals", PositiveIntervals{}, 5, 16},
+		{"ConvexSets", ConvexSets{}, 5, 32},
+		{"Perceptron2D", Perceptron2D(), 3, 8},
+		{"Perceptron2D", Perceptron2D(), 4, 14},
+		{"Perceptron3D", Perceptron{D: 3}, 5, 30},
+		{"SauerBound", SauerBound{Dvc: 3}, 5, 26},
+	}
+	for _, tt := range tests {
+		if got := tt.mh.M(tt.n); got != tt.want {
+			t.Errorf("%s.M(%d) == %v, want %v", tt.name, tt.n, got, tt.want)
+		}
+		if got := tt.mh.LogM(tt.n); math.Abs(got-math.Log(tt.want)) > 1e-9 {
+			t.Errorf("%s.LogM(%d) == %v, want %v", tt.name, tt.n, got, math.Log(tt.want))
+		}
+	}
+}
+
+func TestSampleComplexity(t *testing.T) {
+	var g GeneralizationError
+	g.Dvc = 10
+	g.SetConfidence(0.95)
+	g.Epsilon = 0.05
+	for _, b := range Bounds {
+		s, err := g.SampleComplexity(b)
+		if err != nil {
+			t.Errorf("SampleComplexity(%v) returned error %v", b, err)
+			continue
+		}
+		before, _ := g.ComputeBound(b, s.N-1)
+		if s.Epsilon > g.Epsilon || before <= g.Epsilon {
+			t.Errorf("SampleComplexity(%v) == %v, want the smallest N with epsilon <= %v", b, s.N, g.Epsilon)
+		}
+	}
+	if n, _ := g.LowerBound(); n != 452957 {
+		t.Errorf("LowerBound() == %v, want 452957", n)
+	}
+	// the solvers and the search of N have their own limits.
+	g.IterationLimit = 1
+	if n, err := g.LowerBound(); err != nil || n != 452957 {
+		t.Errorf("LowerBound() with IterationLimit = 1 == %v, %v, want 452957", n, err)
+	}
+	g.EvaluationLimit = 3
+	if _, err := g.SampleComplexity(BoundVC); err == nil {
+		t.Errorf("SampleComplexity() with EvaluationLimit = 3 should return an error")
+	}
+}
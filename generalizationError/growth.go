@@ -0,0 +1,152 @@
+package generalizationError
+
+import (
+	"math"
+)
+
+// GrowthFunction counts the most dichotomies a hypothesis set can implement on any N points.
+// M returns m_H(N) and LogM returns ln(m_H(N)), which can be computed for values of N
+// where m_H(N) itself overflows.
+type GrowthFunction interface {
+	M(n int) float64
+	LogM(n int) float64
+}
+
+// PolynomialBound is the growth function bound used by default:
+// m_H(N) <= 2**N if N <= VC dimention
+// m_H(N) <= N**Dvc if N > VC dimention
+type PolynomialBound struct {
+	Dvc int // VC dimention.
+}
+
+func (p PolynomialBound) M(n int) float64 {
+	if n <= p.Dvc {
+		return math.Pow(float64(2), float64(n))
+	}
+	return math.Pow(float64(n), float64(p.Dvc))
+}
+
+func (p PolynomialBound) LogM(n int) float64 {
+	if n <= p.Dvc {
+		return float64(n) * math.Log(2)
+	}
+	return float64(p.Dvc) * math.Log(float64(n))
+}
+
+// SauerBound is the tightest bound on the growth function of a hypothesis set with VC dimention Dvc:
+// m_H(N) <= Sum(C(N, i)) for i in [0 : Dvc]
+type SauerBound struct {
+	Dvc int // VC dimention.
+}
+
+func (s SauerBound) M(n int) float64 {
+	return binomialSum(n, s.Dvc)
+}
+
+func (s SauerBound) LogM(n int) float64 {
+	return logBinomialSum(n, s.Dvc)
+}
+
+// PositiveRays is the growth function of h(x) = sign(x - a) on the real line:
+// m_H(N) = N + 1
+type PositiveRays struct{}
+
+func (PositiveRays) M(n int) float64 {
+	return float64(n + 1)
+}
+
+func (p PositiveRays) LogM(n int) float64 {
+	return math.Log(p.M(n))
+}
+
+// PositiveIntervals is the growth function of h(x) = +1 inside an interval and -1 outside on the real line:
+// m_H(N) = C(N+1, 2) + 1 = N^2/2 + N/2 + 1
+type PositiveIntervals struct{}
+
+func (PositiveIntervals) M(n int) float64 {
+	N := float64(n)
+	return N*N/float64(2) + N/float64(2) + float64(1)
+}
+
+func (p PositiveIntervals) LogM(n int) float64 {
+	return math.Log(p.M(n))
+}
+
+// ConvexSets is the growth function of h(x) = +1 inside a convex region of the plane:
+// m_H(N) = 2^N, points on a circle can be shattered for every N.
+type ConvexSets struct{}
+
+func (ConvexSets) M(n int) float64 {
+	return math.Pow(float64(2), float64(n))
+}
+
+func (ConvexSets) LogM(n int) float64 {
+	return float64(n) * math.Log(2)
+}
+
+// Perceptron is the growth function of the perceptron h(x) = sign(w'x) in D dimentions (plus the bias w0).
+// Following Cover's function counting theorem for points in general position:
+// m_H(N) = 2 Sum(C(N-1, i)) for i in [0 : D]
+type Perceptron struct {
+	D int // dimention of the input space.
+}
+
+func (p Perceptron) M(n int) float64 {
+	if n == 0 {
+		return 1
+	}
+	return float64(2) * binomialSum(n-1, p.D)
+}
+
+func (p Perceptron) LogM(n int) float64 {
+	if n == 0 {
+		return 0
+	}
+	return math.Log(2) + logBinomialSum(n-1, p.D)
+}
+
+// Perceptron2D is the growth function of the perceptron in the plane:
+// m_H(N) = N^2 - N + 2
+func Perceptron2D() Perceptron {
+	return Perceptron{D: 2}
+}
+
+// binomialSum returns Sum(C(n, i)) for i in [0 : k].
+func binomialSum(n, k int) float64 {
+	sum := float64(0)
+	c := float64(1)
+	for i := 0; i <= k && i <= n; i++ {
+		if i > 0 {
+			c = c * float64(n-i+1) / float64(i)
+		}
+		sum += c
+	}
+	return sum
+}
+
+// logBinomialSum returns ln(Sum(C(n, i))) for i in [0 : k]
+// computed in log space so that it does not overflow.
+func logBinomialSum(n, k int) float64 {
+	if k > n {
+		k = n
+	}
+	logs := make([]float64, k+1)
+	max := math.Inf(-1)
+	for i := 0; i <= k; i++ {
+		logs[i] = logBinomial(n, i)
+		max = math.Max(max, logs[i])
+	}
+	sum := float64(0)
+	for _, l := range logs {
+		sum += math.Exp(l - max)
+	}
+	return max + math.Log(sum)
+}
+
+// logBinomial returns ln(C(n, k)).
+func logBinomial(n, k int) float64 {
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
@@ -0,0 +1,132 @@
+package generalizationError
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Bound identifies one of the generalization bounds of GeneralizationError.
+type Bound int
+
+const (
+	BoundVC         Bound = iota // Original VC bound.
+	BoundRademacher              // Rademacher Penalty Bound.
+	BoundParrondo                // Parrondo and Van den Broek.
+	BoundDevroye                 // Devroye.
+)
+
+// Bounds lists all the generalization bounds.
+var Bounds = []Bound{BoundVC, BoundRademacher, BoundParrondo, BoundDevroye}
+
+func (b Bound) String() string {
+	switch b {
+	case BoundVC:
+		return "Original VC bound"
+	case BoundRademacher:
+		return "Rademacher Penalty Bound"
+	case BoundParrondo:
+		return "Parrondo and Van den Broek"
+	case BoundDevroye:
+		return "Devroye"
+	}
+	return fmt.Sprintf("Bound(%d)", int(b))
+}
+
+// ComputeBound returns the generalization error given by bound b for n samples.
+func (g *GeneralizationError) ComputeBound(b Bound, n int) (float64, error) {
+	switch b {
+	case BoundVC:
+		return g.VCBound(n), nil
+	case BoundRademacher:
+		return g.RademacherPenaltyBound(n), nil
+	case BoundParrondo:
+		return g.ParrondoAndVanDenBroek(n)
+	case BoundDevroye:
+		return g.DevroyeLog(n)
+	}
+	return 0, fmt.Errorf("unknown bound %v", b)
+}
+
+// SampleSize holds the result of a sample complexity computation.
+type SampleSize struct {
+	Bound      Bound   // bound used.
+	N          int     // smallest sample size for which the bound is at most Epsilon.
+	Epsilon    float64 // value of the bound for N samples.
+	Iterations int     // number of evaluations of the bound.
+}
+
+// maxSampleSize is the largest sample size SampleComplexity looks at,
+// Devroye evaluates the growth function at N^2.
+const maxSampleSize = 1 << 31
+
+// SampleComplexity computes the minimum sample size N for which bound b is at most the Epsilon field:
+// 1 - double N until the bound is at most Epsilon.
+// 2 - bisect between N/2 and N for the smallest such N.
+// The bounds decrease with N once they are under 1, so this converges to the minimal N
+// for any Epsilon < 1.
+// It returns an error if no such N is found under 2^31 or within EvaluationLimit evaluations of the bound.
+func (g *GeneralizationError) SampleComplexity(b Bound) (SampleSize, error) {
+	evaluationLimit := g.evaluationLimit()
+	s := SampleSize{Bound: b}
+	if g.Epsilon <= 0 {
+		return s, errors.New("epsilon should be positive")
+	}
+	// eval returns the bound for n samples, +Inf when the bound has no solution.
+	eval := func(n int) (float64, error) {
+		s.Iterations++
+		eps, err := g.ComputeBound(b, n)
+		if err != nil && math.IsInf(eps, 1) {
+			return eps, nil
+		}
+		return eps, err
+	}
+
+	lo, hi := 0, 1
+	hiEps := math.Inf(1)
+	for {
+		if s.Iterations >= evaluationLimit {
+			return s, errors.New("sample complexity iteration limit reached")
+		}
+		eps, err := eval(hi)
+		if err != nil {
+			return s, err
+		}
+		if eps <= g.Epsilon {
+			hiEps = eps
+			break
+		}
+		if hi >= maxSampleSize {
+			return s, fmt.Errorf("%v does not reach epsilon = %v for N under %d", b, g.Epsilon, maxSampleSize)
+		}
+		lo, hi = hi, 2*hi
+	}
+	// the bound is above Epsilon at lo and at most Epsilon at hi.
+	for hi-lo > 1 {
+		if s.Iterations >= evaluationLimit {
+			return s, errors.New("sample complexity iteration limit reached")
+		}
+		mid := lo + (hi-lo)/2
+		eps, err := eval(mid)
+		if err != nil {
+			return s, err
+		}
+		if eps <= g.Epsilon {
+			hi, hiEps = mid, eps
+		} else {
+			lo = mid
+		}
+	}
+	s.N = hi
+	s.Epsilon = hiEps
+	return s, nil
+}
+
+// evaluationLimit returns EvaluationLimit if it is set, 64 otherwise,
+// which is enough to double N up to 2^31 and bisect back down.
+func (g *GeneralizationError) evaluationLimit() int {
+	if g.EvaluationLimit <= 0 {
+		return 64
+	}
+	return g.EvaluationLimit
+}
@@ -0,0 +1,131 @@
+package generalizationError
+
+import (
+	"errors"
+	"math"
+)
+
+// machineEpsilon is the distance from 1 to the next float64.
+const machineEpsilon = 2.220446049250313e-16
+
+// Brent finds a root of f in the interval [a : b] using Brent's method,
+// which combines bisection, secant and inverse quadratic interpolation.
+// f(a) and f(b) should have opposite signs.
+// It returns the root, the number of iterations it took, and an error if
+// the interval does not bracket a root or the iteration limit is reached.
+func Brent(f func(float64) float64, a, b, tolerance float64, iterationLimit int) (float64, int, error) {
+	fa, fb := f(a), f(b)
+	if fa == 0 {
+		return a, 0, nil
+	}
+	if fb == 0 {
+		return b, 0, nil
+	}
+	if (fa > 0) == (fb > 0) {
+		return 0, 0, errors.New("root is not bracketed by the interval")
+	}
+	c, fc := a, fa
+	d := b - a
+	e := d
+	for i := 1; i <= iterationLimit; i++ {
+		if (fb > 0) == (fc > 0) {
+			// keep the root between b and c.
+			c, fc = a, fa
+			d = b - a
+			e = d
+		}
+		if math.Abs(fc) < math.Abs(fb) {
+			a, b, c = b, c, b
+			fa, fb, fc = fb, fc, fb
+		}
+		tol := float64(2)*machineEpsilon*math.Abs(b) + 0.5*tolerance
+		m := 0.5 * (c - b)
+		if math.Abs(m) <= tol || fb == 0 {
+			return b, i, nil
+		}
+		if math.Abs(e) >= tol && math.Abs(fa) > math.Abs(fb) {
+			// try interpolation.
+			var p, q float64
+			s := fb / fa
+			if a == c {
+				// secant method.
+				p = float64(2) * m * s
+				q = float64(1) - s
+			} else {
+				// inverse quadratic interpolation.
+				q = fa / fc
+				r := fb / fc
+				p = s * (float64(2)*m*q*(q-r) - (b-a)*(r-float64(1)))
+				q = (q - float64(1)) * (r - float64(1)) * (s - float64(1))
+			}
+			if p > 0 {
+				q = -q
+			} else {
+				p = -p
+			}
+			if float64(2)*p < math.Min(float64(3)*m*q-math.Abs(tol*q), math.Abs(e*q)) {
+				e = d
+				d = p / q
+			} else {
+				// interpolation failed, use bisection.
+				d = m
+				e = d
+			}
+		} else {
+			// bounds decreasing too slowly, use bisection.
+			d = m
+			e = d
+		}
+		a, fa = b, fb
+		if math.Abs(d) > tol {
+			b += d
+		} else if m > 0 {
+			b += tol
+		} else {
+			b -= tol
+		}
+		fb = f(b)
+	}
+	return b, iterationLimit, errors.New("root finding iteration limit reached")
+}
+
+// maxImplicitEpsilon is the largest epsilon looked at when solving implicit bounds.
+const maxImplicitEpsilon = 1e8
+
+// solveImplicit returns the smallest epsilon >= 0 such that epsilon >= f(epsilon),
+// which is the value of an implicit bound of the form epsilon <= f(epsilon).
+// It returns an error if no such epsilon exists.
+func (g *GeneralizationError) solveImplicit(f func(eps float64) float64) (float64, error) {
+	tolerance, iterationLimit := g.solverLimits()
+	h := func(eps float64) float64 {
+		return eps - f(eps)
+	}
+	if h(0) >= 0 {
+		return 0, nil
+	}
+	// look for an upper end of the interval where h changes sign.
+	// Past maxImplicitEpsilon h is dominated by rounding errors.
+	hi := float64(1)
+	for h(hi) < 0 {
+		hi *= 2
+		if hi > maxImplicitEpsilon || math.IsNaN(h(hi)) {
+			return math.Inf(1), errors.New("implicit bound has no solution")
+		}
+	}
+	eps, _, err := Brent(h, 0, hi, tolerance, iterationLimit)
+	return eps, err
+}
+
+// solverLimits returns the tolerance and iteration limit used by the solvers:
+// Margin and IterationLimit if they are set, 1e-12 and 1000 otherwise.
+func (g *GeneralizationError) solverLimits() (float64, int) {
+	tolerance := g.Margin
+	if tolerance <= 0 {
+		tolerance = 1e-12
+	}
+	iterationLimit := g.IterationLimit
+	if iterationLimit <= 0 {
+		iterationLimit = 1000
+	}
+	return tolerance, iterationLimit
+}
@@ -0,0 +1,191 @@
+package generalizationError
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// SweepRow holds the value of every bound for a sample size N, a VC dimention and a delta.
+type SweepRow struct {
+	N           int
+	Dvc         int
+	Delta       float64
+	Epsilons    []float64 // Epsilons[i] is the value of Bounds[i], NaN when the bound has no solution.
+	Tightest    Bound     // bound with the smallest value, only meaningful when HasTightest is set.
+	HasTightest bool      // false when no bound has a solution.
+}
+
+// Sweep computes all the bounds for every combination of sample size in ns, VC dimention in dvcs
+// and delta in deltas. An empty dvcs or deltas uses the Dvc or Delta fields of g.
+// When the Growth field is set it is used as growth function and the VC dimentions only label the rows.
+func (g *GeneralizationError) Sweep(ns []int, dvcs []int, deltas []float64) ([]SweepRow, error) {
+	if len(ns) == 0 {
+		return nil, errors.New("sweep needs at least one sample size")
+	}
+	if len(dvcs) == 0 {
+		dvcs = []int{g.Dvc}
+	}
+	if len(deltas) == 0 {
+		deltas = []float64{g.Delta}
+	}
+	var rows []SweepRow
+	for _, dvc := range dvcs {
+		for _, delta := range deltas {
+			current := *g
+			current.Dvc = dvc
+			current.SetDelta(delta)
+			for _, n := range ns {
+				if n <= 0 {
+					return nil, fmt.Errorf("sample size should be positive, got %d", n)
+				}
+				row := SweepRow{N: n, Dvc: dvc, Delta: delta, Epsilons: make([]float64, len(Bounds))}
+				tightest := math.Inf(1)
+				for i, b := range Bounds {
+					eps, err := current.ComputeBound(b, n)
+					if err != nil {
+						eps = math.NaN()
+					}
+					row.Epsilons[i] = eps
+					if eps < tightest {
+						tightest = eps
+						row.Tightest = b
+						row.HasTightest = true
+					}
+				}
+				rows = append(rows, row)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// LinearRange returns count sample sizes evenly spaced between from and to.
+func LinearRange(from, to, count int) []int {
+	return sampleRange(float64(from), float64(to), count, func(a, b, t float64) float64 { return a + t*(b-a) })
+}
+
+// LogRange returns count sample sizes spaced logarithmically between from and to.
+// It returns an error if from or to is not positive.
+func LogRange(from, to, count int) ([]int, error) {
+	if from <= 0 || to <= 0 {
+		return nil, errors.New("logarithmic range needs positive bounds")
+	}
+	return sampleRange(float64(from), float64(to), count, func(a, b, t float64) float64 { return a * math.Pow(b/a, t) }), nil
+}
+
+// sampleRange returns the distinct rounded values of interpolate(from, to, t) for count values of t in [0 : 1].
+func sampleRange(from, to float64, count int, interpolate func(a, b, t float64) float64) []int {
+	if count < 2 {
+		return []int{int(from)}
+	}
+	var ns []int
+	for i := 0; i < count; i++ {
+		n := int(math.Round(interpolate(from, to, float64(i)/float64(count-1))))
+		if len(ns) == 0 || ns[len(ns)-1] != n {
+			ns = append(ns, n)
+		}
+	}
+	return ns
+}
+
+// WriteSweepCSV writes the rows to w with the header:
+// n,dvc,delta,<one column per bound>,tightest
+// bounds without solution and the tightest bound of a row without any solution are left empty.
+func WriteSweepCSV(w io.Writer, rows []SweepRow) error {
+	header := []string{"n", "dvc", "delta"}
+	for _, b := range Bounds {
+		header = append(header, b.String())
+	}
+	header = append(header, "tightest")
+	if _, err := fmt.Fprintln(w, strings.Join(header, ",")); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		cells := []string{fmt.Sprint(row.N), fmt.Sprint(row.Dvc), fmt.Sprint(row.Delta)}
+		for _, eps := range row.Epsilons {
+			cells = append(cells, formatEpsilon(eps))
+		}
+		cells = append(cells, row.tightest())
+		if _, err := fmt.Fprintln(w, strings.Join(cells, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteSweepJSON writes the rows to w as a json array of objects,
+// bounds without solution and the tightest bound of a row without any solution are written as null.
+func WriteSweepJSON(w io.Writer, rows []SweepRow) error {
+	type jsonRow struct {
+		N        int                 `json:"n"`
+		Dvc      int                 `json:"dvc"`
+		Delta    float64             `json:"delta"`
+		Bounds   map[string]*float64 `json:"bounds"`
+		Tightest *string             `json:"tightest"`
+	}
+	out := make([]jsonRow, len(rows))
+	for i, row := range rows {
+		out[i] = jsonRow{N: row.N, Dvc: row.Dvc, Delta: row.Delta, Bounds: make(map[string]*float64)}
+		if row.HasTightest {
+			tightest := row.Tightest.String()
+			out[i].Tightest = &tightest
+		}
+		for j, b := range Bounds {
+			if eps := row.Epsilons[j]; !math.IsNaN(eps) && !math.IsInf(eps, 0) {
+				out[i].Bounds[b.String()] = &eps
+			} else {
+				out[i].Bounds[b.String()] = nil
+			}
+		}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(out)
+}
+
+// WriteSweepMarkdown writes the rows to w as a markdown table,
+// the tightest bound of each row is written in bold.
+func WriteSweepMarkdown(w io.Writer, rows []SweepRow) error {
+	header := "| N | dvc | delta |"
+	separator := "|---:|---:|---:|"
+	for _, b := range Bounds {
+		header += " " + b.String() + " |"
+		separator += "---:|"
+	}
+	if _, err := fmt.Fprintf(w, "%s\n%s\n", header, separator); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		line := fmt.Sprintf("| %d | %d | %v |", row.N, row.Dvc, row.Delta)
+		for i, eps := range row.Epsilons {
+			cell := formatEpsilon(eps)
+			if row.HasTightest && Bounds[i] == row.Tightest {
+				cell = "**" + cell + "**"
+			}
+			line += " " + cell + " |"
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// tightest returns the name of the tightest bound of the row, an empty string if no bound has a solution.
+func (row SweepRow) tightest() string {
+	if !row.HasTightest {
+		return ""
+	}
+	return row.Tightest.String()
+}
+
+func formatEpsilon(eps float64) string {
+	if math.IsNaN(eps) {
+		return ""
+	}
+	return fmt.Sprintf("%.5f", eps)
+}
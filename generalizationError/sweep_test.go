@@ -0,0 +1,95 @@
+package generalizationError
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+)
+
+// nanGrowth is a growth function for which no bound has a solution.
+type nanGrowth struct{}
+
+func (nanGrowth) M(n int) float64    { return math.NaN() }
+func (nanGrowth) LogM(n int) float64 { return math.NaN() }
+
+func TestSweep(t *testing.T) {
+	var g GeneralizationError
+	rows, err := g.Sweep([]int{5, 10000}, []int{10, 50}, []float64{0.05})
+	if err != nil {
+		t.Fatalf("Sweep returned error %v", err)
+	}
+	if len(rows) != 4 {
+		t.Fatalf("Sweep returned %d rows, want 4", len(rows))
+	}
+	for _, row := range rows {
+		if !row.HasTightest {
+			t.Errorf("row %+v has no tightest bound", row)
+			continue
+		}
+		tightest := row.Epsilons[row.Tightest]
+		for i, eps := range row.Epsilons {
+			if eps < tightest {
+				t.Errorf("row %+v: %v = %v is tighter than %v", row, Bounds[i], eps, row.Tightest)
+			}
+		}
+	}
+	if _, err := g.Sweep([]int{0}, nil, nil); err == nil {
+		t.Errorf("Sweep with a sample size of 0 should return an error")
+	}
+}
+
+func TestSweepWithoutSolution(t *testing.T) {
+	g := GeneralizationError{Growth: nanGrowth{}}
+	g.SetDelta(0.05)
+	rows, err := g.Sweep([]int{100}, nil, nil)
+	if err != nil {
+		t.Fatalf("Sweep returned error %v", err)
+	}
+	if rows[0].HasTightest {
+		t.Fatalf("row %+v should have no tightest bound", rows[0])
+	}
+
+	var csv bytes.Buffer
+	if err := WriteSweepCSV(&csv, rows); err != nil {
+		t.Fatalf("WriteSweepCSV returned error %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(csv.String()), "\n")
+	if want := "100,0,0.05" + strings.Repeat(",", len(Bounds)+1); lines[1] != want {
+		t.Errorf("csv row == %q, want %q", lines[1], want)
+	}
+
+	var js bytes.Buffer
+	if err := WriteSweepJSON(&js, rows); err != nil {
+		t.Fatalf("WriteSweepJSON returned error %v", err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(js.Bytes(), &decoded); err != nil {
+		t.Fatalf("cannot decode json: %v", err)
+	}
+	if tightest, ok := decoded[0]["tightest"]; !ok || tightest != nil {
+		t.Errorf("json tightest == %v, want null", tightest)
+	}
+
+	var md bytes.Buffer
+	if err := WriteSweepMarkdown(&md, rows); err != nil {
+		t.Fatalf("WriteSweepMarkdown returned error %v", err)
+	}
+	if strings.Contains(md.String(), "**") {
+		t.Errorf("markdown should not mark a tightest bound:\n%s", md.String())
+	}
+}
+
+func TestRanges(t *testing.T) {
+	if got := LinearRange(10, 50, 5); len(got) != 5 || got[0] != 10 || got[2] != 30 || got[4] != 50 {
+		t.Errorf("LinearRange(10, 50, 5) == %v, want [10 20 30 40 50]", got)
+	}
+	got, err := LogRange(10, 10000, 4)
+	if err != nil || len(got) != 4 || got[1] != 100 || got[3] != 10000 {
+		t.Errorf("LogRange(10, 10000, 4) == %v, %v, want [10 100 1000 10000]", got, err)
+	}
+	if _, err := LogRange(0, 100, 4); err == nil {
+		t.Errorf("LogRange(0, 100, 4) should return an error")
+	}
+}
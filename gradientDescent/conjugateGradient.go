@@ -0,0 +1,102 @@
+package GradientDescent
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// ConjugateGradientMethod selects the formula used to compute beta in
+// the nonlinear conjugate gradient algorithm.
+type ConjugateGradientMethod int
+
+const (
+	FletcherReeves ConjugateGradientMethod = iota // beta = g1'g1 / g0'g0
+	PolakRibiere                                  // beta = max(0, g1'(g1 - g0) / g0'g0)
+)
+
+// ConjugateGradient holds all the information needed to run the nonlinear conjugate gradient algorithm.
+// It stops following the embedded StoppingCriteria.
+type ConjugateGradient struct {
+	Method           ConjugateGradientMethod // formula used for beta.
+	StoppingCriteria                         // conditions to stop the minimization.
+	LineSearch       *WolfeLineSearch        // line search used to choose the step length.
+	X                []float64               // current point.
+	Iterations       int                     // number of iterations of the last run.
+	Reason           StopReason              // why the last run stopped.
+	Record           bool                    // when set, every iterate is recorded in Path.
+	Path             Trajectory              // iterates of the last run, including the starting point.
+}
+
+// NewConjugateGradient is a constructor of a basic conjugate gradient optimizer:
+// Method = PolakRibiere
+// GradientLimit = 1e-6
+// IterationLimit = 1000
+// The line search uses C2 = 0.1 as conjugate gradient needs a more exact step than quasi Newton methods.
+func NewConjugateGradient() *ConjugateGradient {
+	cg := ConjugateGradient{}
+	cg.Method = PolakRibiere
+	cg.GradientLimit = 1e-6
+	cg.IterationLimit = 1000
+	cg.LineSearch = NewWolfeLineSearch()
+	cg.LineSearch.C2 = 0.1
+	return &cg
+}
+
+// Minimize runs nonlinear conjugate gradient on objective o starting at x0:
+// 1 - find a step length alpha along p satisfying the Wolfe conditions
+// 2 - compute beta with the selected method
+// 3 - update the direction p = -grad + beta*p
+// The direction is reset to the steepest descent every len(x0) iterations
+// or when it is not a descent direction anymore.
+// It returns an error unless it stopped on a convergence criterion.
+func (cg *ConjugateGradient) Minimize(o Objective, x0 []float64) ([]float64, error) {
+	start := time.Now()
+	n := len(x0)
+	cg.X = make([]float64, n)
+	copy(cg.X, x0)
+	cg.Iterations = 0
+	cg.Path = nil
+
+	f := o.F(cg.X)
+	g := o.Grad(cg.X)
+	fPrev := math.NaN()
+	p := axpy(float64(-1), g, make([]float64, n))
+	if cg.Record {
+		cg.Path = append(cg.Path, newIterate(cg.X, f, g))
+	}
+	for {
+		if cg.Reason = cg.check(cg.Iterations, f, fPrev, norm(g), start); cg.Reason != NotStopped {
+			return cg.X, stopError("conjugate gradient", cg.Reason)
+		}
+		alpha, fNew, gNew, err := cg.LineSearch.Search(o, cg.X, p, f, g)
+		if err != nil {
+			cg.Reason = LineSearchFailed
+			return cg.X, err
+		}
+		cg.X = axpy(alpha, p, cg.X)
+
+		var beta float64
+		switch cg.Method {
+		case FletcherReeves:
+			beta = dot(gNew, gNew) / dot(g, g)
+		case PolakRibiere:
+			beta = math.Max(float64(0), dot(gNew, sub(gNew, g))/dot(g, g))
+		default:
+			return cg.X, errors.New("unknown conjugate gradient method")
+		}
+		if (cg.Iterations+1)%n == 0 {
+			beta = 0
+		}
+		p = axpy(beta, p, axpy(float64(-1), gNew, make([]float64, n)))
+		if dot(p, gNew) >= 0 {
+			p = axpy(float64(-1), gNew, make([]float64, n))
+		}
+		fPrev = f
+		f, g = fNew, gNew
+		cg.Iterations++
+		if cg.Record {
+			cg.Path = append(cg.Path, newIterate(cg.X, f, g))
+		}
+	}
+}
@@ -2,77 +2,194 @@ package GradientDescent
 
 import (
 	"math"
+	"time"
 )
 
+// GradientDescent moves both U and V along the negative gradient of the error surface.
+// It stops following the embedded StoppingCriteria.
 type GradientDescent struct {
-	Eta        float64 // learning rate
-	U          float64
-	V          float64
-	ErrorLimit float64
+	Eta              float64 // learning rate
+	U                float64
+	V                float64
+	LineSearch       *BacktrackingLineSearch // when set, the step length is chosen by line search instead of Eta.
+	StoppingCriteria                         // conditions to stop the descent.
+	Record           bool                    // when set, every iterate is recorded in Path.
+	Path             Trajectory              // iterates of the last descent, including the starting point.
 }
 
+// CoordinateDescent moves U along its partial derivative and then V along its partial derivative.
+// It stops following the embedded StoppingCriteria.
 type CoordinateDescent struct {
-	Eta            float64 // learning rate
-	U              float64
-	V              float64
-	ErrorLimit     float64
-	IterationLimit int
+	Eta              float64 // learning rate
+	U                float64
+	V                float64
+	LineSearch       *BacktrackingLineSearch // when set, the step length is chosen by line search instead of Eta.
+	StoppingCriteria                         // conditions to stop the descent.
+	Record           bool                    // when set, every iterate is recorded in Path.
+	Path             Trajectory              // iterates of the last descent, including the starting point and the point after each step on U.
 }
 
+// Surface is the non linear error surface used by GradientDescent and CoordinateDescent
+// as an Objective of x = (u, v).
+var Surface = Objective{
+	F: func(x []float64) float64 {
+		return surfaceErr(x[0], x[1])
+	},
+	Grad: func(x []float64) []float64 {
+		return []float64{surfaceDerivU(x[0], x[1]), surfaceDerivV(x[0], x[1])}
+	},
+}
+
+// E runs the coordinate descent and returns the number of iterations done.
 func (cd *CoordinateDescent) E() int {
+	return cd.Descend().Iterations
+}
+
+// Descend runs the coordinate descent until one of the stopping criteria is met.
+// Each iteration does a step on U followed by a step on V.
+func (cd *CoordinateDescent) Descend() Result {
+	start := time.Now()
+	errPrev := math.NaN()
 	iteration := 0
+	cd.Path = nil
 	for {
-		if cd.IterationLimit < iteration {
-			break
+		e := cd.Err()
+		gradientNorm := math.Hypot(cd.DerivU(), cd.DerivV())
+		if cd.Record {
+			cd.Path = append(cd.Path, Iterate{U: cd.U, V: cd.V, Err: e, GradientNorm: gradientNorm})
+		}
+		if reason := cd.check(iteration, e, errPrev, gradientNorm, start); reason != NotStopped {
+			return cd.result(iteration, start, reason)
+		}
+
+		etaU, err := cd.step([]float64{-cd.DerivU(), 0})
+		if err != nil {
+			return cd.result(iteration, start, LineSearchFailed)
+		}
+		cd.U = cd.U - etaU*cd.DerivU()
+		if cd.Record {
+			cd.Path = append(cd.Path, Iterate{U: cd.U, V: cd.V, Err: cd.Err(), GradientNorm: math.Hypot(cd.DerivU(), cd.DerivV())})
+		}
+		etaV, err := cd.step([]float64{0, -cd.DerivV()})
+		if err != nil {
+			return cd.result(iteration, start, LineSearchFailed)
 		}
+		cd.V = cd.V - etaV*cd.DerivV()
 
-		cd.U = cd.U - cd.Eta*cd.DerivU()
-		dV := cd.V - cd.Eta*cd.DerivV()
-		cd.V = dV
+		errPrev = e
 		iteration++
 	}
-	return iteration
 }
 
+// step returns the step length along direction p, Eta if there is no line search.
+func (cd *CoordinateDescent) step(p []float64) (float64, error) {
+	if norm(p) == 0 {
+		return 0, nil
+	}
+	if cd.LineSearch == nil {
+		return cd.Eta, nil
+	}
+	x := []float64{cd.U, cd.V}
+	return cd.LineSearch.Search(Surface, x, p, cd.Err(), Surface.Grad(x))
+}
+
+func (cd *CoordinateDescent) result(iteration int, start time.Time, reason StopReason) Result {
+	return Result{
+		X:            []float64{cd.U, cd.V},
+		Err:          cd.Err(),
+		GradientNorm: math.Hypot(cd.DerivU(), cd.DerivV()),
+		Iterations:   iteration,
+		Elapsed:      time.Since(start),
+		Reason:       reason,
+	}
+}
+
+// E runs the gradient descent and returns the number of iterations done.
 func (gd *GradientDescent) E() int {
-	iteration := 0
+	return gd.Descend().Iterations
+}
 
+// Descend runs the gradient descent until one of the stopping criteria is met.
+func (gd *GradientDescent) Descend() Result {
+	start := time.Now()
+	errPrev := math.NaN()
+	iteration := 0
+	gd.Path = nil
 	for {
-		if gd.Err() < gd.ErrorLimit {
-			break
+		e := gd.Err()
+		gradientNorm := math.Hypot(gd.DerivU(), gd.DerivV())
+		if gd.Record {
+			gd.Path = append(gd.Path, Iterate{U: gd.U, V: gd.V, Err: e, GradientNorm: gradientNorm})
+		}
+		if reason := gd.check(iteration, e, errPrev, gradientNorm, start); reason != NotStopped {
+			return gd.result(iteration, start, reason)
 		}
-		dU := gd.U - gd.Eta*gd.DerivU()
-		dV := gd.V - gd.Eta*gd.DerivV()
+
+		eta := gd.Eta
+		if gd.LineSearch != nil && gradientNorm > 0 {
+			x := []float64{gd.U, gd.V}
+			g := Surface.Grad(x)
+			alpha, err := gd.LineSearch.Search(Surface, x, []float64{-g[0], -g[1]}, e, g)
+			if err != nil {
+				return gd.result(iteration, start, LineSearchFailed)
+			}
+			eta = alpha
+		}
+		dU := gd.U - eta*gd.DerivU()
+		dV := gd.V - eta*gd.DerivV()
 		gd.U = dU
 		gd.V = dV
 
+		errPrev = e
 		iteration++
 	}
-	return iteration
+}
+
+func (gd *GradientDescent) result(iteration int, start time.Time, reason StopReason) Result {
+	return Result{
+		X:            []float64{gd.U, gd.V},
+		Err:          gd.Err(),
+		GradientNorm: math.Hypot(gd.DerivU(), gd.DerivV()),
+		Iterations:   iteration,
+		Elapsed:      time.Since(start),
+		Reason:       reason,
+	}
 }
 
 func (gd *GradientDescent) DerivU() float64 {
-	return float64(2) * (math.Exp(gd.V) + float64(2)*gd.V*math.Exp(-gd.U)) * (gd.U*math.Exp(gd.V) - float64(2)*gd.V*math.Exp(-gd.U))
+	return surfaceDerivU(gd.U, gd.V)
 }
 
 func (gd *GradientDescent) DerivV() float64 {
-	return float64(2) * (gd.U*math.Exp(gd.V) - float64(2)*math.Exp(-gd.U)) * (gd.U*math.Exp(gd.V) - float64(2)*gd.V*math.Exp(-gd.U))
+	return surfaceDerivV(gd.U, gd.V)
 }
 
 func (cd *CoordinateDescent) DerivU() float64 {
-	return float64(2) * (math.Exp(cd.V) + float64(2)*cd.V*math.Exp(-cd.U)) * (cd.U*math.Exp(cd.V) - float64(2)*cd.V*math.Exp(-cd.U))
+	return surfaceDerivU(cd.U, cd.V)
 }
 
 func (cd *CoordinateDescent) DerivV() float64 {
-	return float64(2) * (cd.U*math.Exp(cd.V) - float64(2)*math.Exp(-cd.U)) * (cd.U*math.Exp(cd.V) - float64(2)*cd.V*math.Exp(-cd.U))
+	return surfaceDerivV(cd.U, cd.V)
 }
 
-// Non linear error surface:
-// (u^v - 2v^-u)^2
 func (gd *GradientDescent) Err() float64 {
-	return math.Pow(gd.U*math.Exp(gd.V)-float64(2)*gd.V*math.Exp(-gd.U), 2)
+	return surfaceErr(gd.U, gd.V)
 }
 
 func (cd *CoordinateDescent) Err() float64 {
-	return math.Pow(cd.U*math.Exp(cd.V)-float64(2)*cd.V*math.Exp(-cd.U), 2)
+	return surfaceErr(cd.U, cd.V)
+}
+
+// Non linear error surface:
+// (u^v - 2v^-u)^2
+func surfaceErr(u, v float64) float64 {
+	return math.Pow(u*math.Exp(v)-float64(2)*v*math.Exp(-u), 2)
+}
+
+func surfaceDerivU(u, v float64) float64 {
+	return float64(2) * (math.Exp(v) + float64(2)*v*math.Exp(-u)) * (u*math.Exp(v) - float64(2)*v*math.Exp(-u))
+}
+
+func surfaceDerivV(u, v float64) float64 {
+	return float64(2) * (u*math.Exp(v) - float64(2)*math.Exp(-u)) * (u*math.Exp(v) - float64(2)*v*math.Exp(-u))
 }
@@ -0,0 +1,99 @@
+package GradientDescent
+
+import (
+	"math"
+	"testing"
+)
+
+// rosenbrock is the function f(x, y) = (1 - x)^2 + 100(y - x^2)^2 with minimum at (1, 1).
+var rosenbrock = Objective{
+	F: func(x []float64) float64 {
+		return math.Pow(1-x[0], 2) + 100*math.Pow(x[1]-x[0]*x[0], 2)
+	},
+	Grad: func(x []float64) []float64 {
+		return []float64{
+			-2*(1-x[0]) - 400*x[0]*(x[1]-x[0]*x[0]),
+			200 * (x[1] - x[0]*x[0]),
+		}
+	},
+}
+
+func TestMinimizers(t *testing.T) {
+	fr := NewConjugateGradient()
+	fr.Method = FletcherReeves
+	fr.IterationLimit = 10000
+	minimizers := map[string]Minimizer{
+		"BFGS":            NewBFGS(),
+		"L-BFGS":          NewLBFGS(),
+		"Polak Ribiere":   NewConjugateGradient(),
+		"Fletcher Reeves": fr,
+	}
+	for name, m := range minimizers {
+		x, err := m.Minimize(rosenbrock, []float64{-1.2, 1})
+		if err != nil {
+			t.Errorf("%s: Minimize returned error %v", name, err)
+			continue
+		}
+		if math.Abs(x[0]-1) > 1e-4 || math.Abs(x[1]-1) > 1e-4 {
+			t.Errorf("%s: Minimize == %v, want [1 1]", name, x)
+		}
+	}
+}
+
+func TestDescendStops(t *testing.T) {
+	var gd GradientDescent
+	gd.U, gd.V, gd.Eta = 1, 1, 0.1
+	gd.ErrorLimit = 10e-14
+	if res := gd.Descend(); res.Reason != ErrorLimitReached || res.Iterations != 10 {
+		t.Errorf("GradientDescent.Descend() == %v, want error limit reached after 10 iterations", res)
+	}
+
+	// the minimum of the surface is 0, so a negative error limit is never reached.
+	gd.U, gd.V = 1, 1
+	gd.ErrorLimit = -1
+	gd.IterationLimit = 50
+	if res := gd.Descend(); res.Reason != IterationLimitReached || res.Iterations != 50 {
+		t.Errorf("GradientDescent.Descend() == %v, want iteration limit reached after 50 iterations", res)
+	}
+
+	var cd CoordinateDescent
+	cd.U, cd.V, cd.Eta = 1, 1, 0.1
+	cd.IterationLimit = 15
+	if res := cd.Descend(); res.Reason != IterationLimitReached || math.Abs(res.Err-0.1398) > 1e-3 {
+		t.Errorf("CoordinateDescent.Descend() == %v, want error close to 0.1398", res)
+	}
+
+	cd.U, cd.V = 1, 1
+	cd.IterationLimit = 0
+	cd.LineSearch = NewBacktrackingLineSearch()
+	cd.RelativeChange = 1e-12
+	if res := cd.Descend(); res.Reason == IterationLimitReached || res.Reason == LineSearchFailed {
+		t.Errorf("CoordinateDescent.Descend() == %v, want convergence", res)
+	}
+}
+
+func TestMinimizersStop(t *testing.T) {
+	b := NewBFGS()
+	l := NewLBFGS()
+	cg := NewConjugateGradient()
+	for name, m := range map[string]struct {
+		Minimizer
+		criteria *StoppingCriteria
+		reason   *StopReason
+	}{
+		"BFGS":               {b, &b.StoppingCriteria, &b.Reason},
+		"L-BFGS":             {l, &l.StoppingCriteria, &l.Reason},
+		"conjugate gradient": {cg, &cg.StoppingCriteria, &cg.Reason},
+	} {
+		m.criteria.IterationLimit = 3
+		if _, err := m.Minimize(rosenbrock, []float64{-1.2, 1}); err == nil || *m.reason != IterationLimitReached {
+			t.Errorf("%s: Minimize stopped with %v, %v, want iteration limit reached and an error", name, *m.reason, err)
+		}
+		m.criteria.IterationLimit = 0
+		m.criteria.GradientLimit = 0
+		m.criteria.ErrorLimit = 1e-6
+		if _, err := m.Minimize(rosenbrock, []float64{-1.2, 1}); err != nil || *m.reason != ErrorLimitReached {
+			t.Errorf("%s: Minimize stopped with %v, %v, want error limit reached", name, *m.reason, err)
+		}
+	}
+}
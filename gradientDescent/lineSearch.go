@@ -0,0 +1,192 @@
+package GradientDescent
+
+import (
+	"errors"
+	"math"
+)
+
+// Objective is a differentiable function f: R^n -> R that should be minimized.
+// F returns the value of the function at x and Grad its gradient vector at x.
+type Objective struct {
+	F    func(x []float64) float64
+	Grad func(x []float64) []float64
+}
+
+// BacktrackingLineSearch holds the parameters of a line search that starts with a step Alpha0
+// along a descent direction p and shrinks it by a factor Rho until the Armijo condition holds:
+// f(x + alpha*p) <= f(x) + C1*alpha*grad(x)'p
+type BacktrackingLineSearch struct {
+	Alpha0         float64 // initial step length.
+	Rho            float64 // contraction factor, 0 < Rho < 1.
+	C1             float64 // sufficient decrease constant, 0 < C1 < 1.
+	IterationLimit int     // maximum number of contractions.
+}
+
+// NewBacktrackingLineSearch is a constructor of a line search with the usual constants:
+// Alpha0 = 1
+// Rho = 0.5
+// C1 = 1e-4
+// IterationLimit = 50
+func NewBacktrackingLineSearch() *BacktrackingLineSearch {
+	ls := BacktrackingLineSearch{}
+	ls.Alpha0 = 1
+	ls.Rho = 0.5
+	ls.C1 = 1e-4
+	ls.IterationLimit = 50
+	return &ls
+}
+
+// Search returns a step length alpha satisfying the Armijo condition along p
+// starting from x, where f0 and g0 are the value and the gradient of the objective at x.
+func (ls *BacktrackingLineSearch) Search(o Objective, x, p []float64, f0 float64, g0 []float64) (float64, error) {
+	d0 := dot(g0, p)
+	if d0 >= 0 {
+		return 0, errors.New("line search direction is not a descent direction")
+	}
+	alpha := ls.Alpha0
+	for i := 0; i < ls.IterationLimit; i++ {
+		if o.F(axpy(alpha, p, x)) <= f0+ls.C1*alpha*d0 {
+			return alpha, nil
+		}
+		alpha *= ls.Rho
+	}
+	return 0, errors.New("line search could not satisfy the Armijo condition")
+}
+
+// WolfeLineSearch holds the parameters of a line search that finds a step length
+// alpha along a descent direction p satisfying the strong Wolfe conditions:
+// f(x + alpha*p) <= f(x) + C1*alpha*grad(x)'p      (sufficient decrease)
+// |grad(x + alpha*p)'p| <= C2*|grad(x)'p|          (curvature)
+type WolfeLineSearch struct {
+	C1             float64 // sufficient decrease constant, 0 < C1 < C2.
+	C2             float64 // curvature constant, C1 < C2 < 1.
+	MaxStep        float64 // largest step length allowed.
+	IterationLimit int     // maximum number of trial steps.
+}
+
+// NewWolfeLineSearch is a constructor of a line search with the usual constants:
+// C1 = 1e-4
+// C2 = 0.9
+// MaxStep = 100
+// IterationLimit = 50
+func NewWolfeLineSearch() *WolfeLineSearch {
+	ls := WolfeLineSearch{}
+	ls.C1 = 1e-4
+	ls.C2 = 0.9
+	ls.MaxStep = 100
+	ls.IterationLimit = 50
+	return &ls
+}
+
+// Search returns a step length alpha satisfying the strong Wolfe conditions along p
+// starting from x, where f0 and g0 are the value and the gradient of the objective at x.
+// It also returns the value and the gradient of the objective at the new point x + alpha*p.
+// This follows algorithms 3.5 and 3.6 of Nocedal and Wright, Numerical Optimization.
+func (ls *WolfeLineSearch) Search(o Objective, x, p []float64, f0 float64, g0 []float64) (float64, float64, []float64, error) {
+	d0 := dot(g0, p)
+	if d0 >= 0 {
+		return 0, f0, g0, errors.New("line search direction is not a descent direction")
+	}
+
+	phi := func(alpha float64) (float64, []float64, float64) {
+		xa := axpy(alpha, p, x)
+		g := o.Grad(xa)
+		return o.F(xa), g, dot(g, p)
+	}
+
+	alphaPrev, fPrev, dPrev := float64(0), f0, d0
+	alpha := math.Min(float64(1), ls.MaxStep)
+	for i := 0; i < ls.IterationLimit; i++ {
+		f, g, d := phi(alpha)
+		if f > f0+ls.C1*alpha*d0 || (i > 0 && f >= fPrev) {
+			return ls.zoom(phi, f0, d0, alphaPrev, fPrev, dPrev, alpha, f, d)
+		}
+		if math.Abs(d) <= -ls.C2*d0 {
+			return alpha, f, g, nil
+		}
+		if d >= 0 {
+			return ls.zoom(phi, f0, d0, alpha, f, d, alphaPrev, fPrev, dPrev)
+		}
+		if alpha >= ls.MaxStep {
+			return alpha, f, g, nil
+		}
+		alphaPrev, fPrev, dPrev = alpha, f, d
+		alpha = math.Min(float64(2)*alpha, ls.MaxStep)
+	}
+	return 0, f0, g0, errors.New("line search iteration limit reached")
+}
+
+// zoom narrows the interval [lo, hi] which is known to contain a step length
+// satisfying the strong Wolfe conditions, until one is found.
+func (ls *WolfeLineSearch) zoom(phi func(float64) (float64, []float64, float64), f0, d0, lo, fLo, dLo, hi, fHi, dHi float64) (float64, float64, []float64, error) {
+	for i := 0; i < ls.IterationLimit; i++ {
+		alpha := cubicMinimizer(lo, fLo, dLo, hi, fHi, dHi)
+		// keep the trial step safely inside the interval, bisect otherwise.
+		a, b := math.Min(lo, hi), math.Max(lo, hi)
+		margin := 0.1 * (b - a)
+		if math.IsNaN(alpha) || alpha < a+margin || alpha > b-margin {
+			alpha = (lo + hi) / float64(2)
+		}
+		f, g, d := phi(alpha)
+		if f > f0+ls.C1*alpha*d0 || f >= fLo {
+			hi, fHi, dHi = alpha, f, d
+		} else {
+			if math.Abs(d) <= -ls.C2*d0 {
+				return alpha, f, g, nil
+			}
+			if d*(hi-lo) >= 0 {
+				hi, fHi, dHi = lo, fLo, dLo
+			}
+			lo, fLo, dLo = alpha, f, d
+		}
+		if math.Abs(hi-lo) < 1e-16 {
+			break
+		}
+	}
+	return 0, f0, nil, errors.New("line search could not satisfy the Wolfe conditions")
+}
+
+// cubicMinimizer returns the minimizer of the cubic interpolating
+// f and its derivative d at points a and b.
+func cubicMinimizer(a, fa, da, b, fb, db float64) float64 {
+	d1 := da + db - float64(3)*(fa-fb)/(a-b)
+	s := d1*d1 - da*db
+	if s < 0 {
+		return math.NaN()
+	}
+	d2 := math.Sqrt(s)
+	if b < a {
+		d2 = -d2
+	}
+	return b - (b-a)*(db+d2-d1)/(db-da+float64(2)*d2)
+}
+
+func dot(a, b []float64) float64 {
+	var ret float64
+	for i := range a {
+		ret += a[i] * b[i]
+	}
+	return ret
+}
+
+func norm(v []float64) float64 {
+	return math.Sqrt(dot(v, v))
+}
+
+// axpy returns the vector a*x + y.
+func axpy(a float64, x, y []float64) []float64 {
+	ret := make([]float64, len(y))
+	for i := range y {
+		ret[i] = a*x[i] + y[i]
+	}
+	return ret
+}
+
+// sub returns the vector a - b.
+func sub(a, b []float64) []float64 {
+	ret := make([]float64, len(a))
+	for i := range a {
+		ret[i] = a[i] - b[i]
+	}
+	return ret
+}
@@ -0,0 +1,227 @@
+package GradientDescent
+
+import (
+	"errors"
+	"math"
+	"time"
+)
+
+// Minimizer is implemented by the optimizers that minimize a differentiable Objective
+// starting from x0, and return the point they converged to.
+type Minimizer interface {
+	Minimize(o Objective, x0 []float64) ([]float64, error)
+}
+
+// BFGS holds all the information needed to run the Broyden Fletcher Goldfarb Shanno algorithm.
+// It keeps a dense approximation H of the inverse of the Hessian matrix.
+// It stops following the embedded StoppingCriteria.
+type BFGS struct {
+	StoppingCriteria                  // conditions to stop the minimization.
+	LineSearch       *WolfeLineSearch // line search used to choose the step length.
+	X                []float64        // current point.
+	H                [][]float64      // current approximation of the inverse Hessian.
+	Iterations       int              // number of iterations of the last run.
+	Reason           StopReason       // why the last run stopped.
+	Record           bool             // when set, every iterate is recorded in Path.
+	Path             Trajectory       // iterates of the last run, including the starting point.
+}
+
+// NewBFGS is a constructor of a basic BFGS optimizer:
+// GradientLimit = 1e-6
+// IterationLimit = 1000
+func NewBFGS() *BFGS {
+	b := BFGS{}
+	b.GradientLimit = 1e-6
+	b.IterationLimit = 1000
+	b.LineSearch = NewWolfeLineSearch()
+	return &b
+}
+
+// Minimize runs BFGS on objective o starting at x0:
+// 1 - compute the search direction p = -H * grad
+// 2 - find a step length alpha along p satisfying the Wolfe conditions
+// 3 - update H with s = alpha*p and y = grad(x + s) - grad(x)
+// It returns an error unless it stopped on a convergence criterion.
+func (b *BFGS) Minimize(o Objective, x0 []float64) ([]float64, error) {
+	start := time.Now()
+	n := len(x0)
+	b.X = make([]float64, n)
+	copy(b.X, x0)
+	b.H = identity(n)
+	b.Iterations = 0
+	b.Path = nil
+
+	f := o.F(b.X)
+	g := o.Grad(b.X)
+	fPrev := math.NaN()
+	if b.Record {
+		b.Path = append(b.Path, newIterate(b.X, f, g))
+	}
+	for {
+		if b.Reason = b.check(b.Iterations, f, fPrev, norm(g), start); b.Reason != NotStopped {
+			return b.X, stopError("BFGS", b.Reason)
+		}
+		p := matVec(b.H, g)
+		for i := range p {
+			p[i] = -p[i]
+		}
+		alpha, fNew, gNew, err := b.LineSearch.Search(o, b.X, p, f, g)
+		if err != nil {
+			b.Reason = LineSearchFailed
+			return b.X, err
+		}
+		xNew := axpy(alpha, p, b.X)
+		s := sub(xNew, b.X)
+		y := sub(gNew, g)
+		sy := dot(s, y)
+		if sy > 1e-10 {
+			if b.Iterations == 0 {
+				// scale the initial approximation before the first update.
+				scale := sy / dot(y, y)
+				for i := range b.H {
+					b.H[i][i] = scale
+				}
+			}
+			b.updateInverseHessian(s, y, sy)
+		}
+		fPrev = f
+		b.X, f, g = xNew, fNew, gNew
+		b.Iterations++
+		if b.Record {
+			b.Path = append(b.Path, newIterate(b.X, f, g))
+		}
+	}
+}
+
+// updateInverseHessian applies the BFGS update:
+// H = (I - rho*s*y')H(I - rho*y*s') + rho*s*s'  with rho = 1/(y's)
+func (b *BFGS) updateInverseHessian(s, y []float64, sy float64) {
+	rho := float64(1) / sy
+	hy := matVec(b.H, y)
+	yhy := dot(y, hy)
+	for i := range b.H {
+		for j := range b.H[i] {
+			b.H[i][j] += -rho*(hy[i]*s[j]+s[i]*hy[j]) + (rho*rho*yhy+rho)*s[i]*s[j]
+		}
+	}
+}
+
+// LBFGS holds all the information needed to run the limited memory BFGS algorithm.
+// Instead of a dense inverse Hessian it keeps the last M pairs (s, y).
+// It stops following the embedded StoppingCriteria.
+type LBFGS struct {
+	M                int              // number of (s, y) pairs kept in history.
+	StoppingCriteria                  // conditions to stop the minimization.
+	LineSearch       *WolfeLineSearch // line search used to choose the step length.
+	X                []float64        // current point.
+	Iterations       int              // number of iterations of the last run.
+	Reason           StopReason       // why the last run stopped.
+	Record           bool             // when set, every iterate is recorded in Path.
+	Path             Trajectory       // iterates of the last run, including the starting point.
+}
+
+// NewLBFGS is a constructor of a basic L-BFGS optimizer:
+// M = 10
+// GradientLimit = 1e-6
+// IterationLimit = 1000
+func NewLBFGS() *LBFGS {
+	l := LBFGS{}
+	l.M = 10
+	l.GradientLimit = 1e-6
+	l.IterationLimit = 1000
+	l.LineSearch = NewWolfeLineSearch()
+	return &l
+}
+
+// Minimize runs L-BFGS on objective o starting at x0.
+// The search direction is computed with the two loop recursion over the history of (s, y) pairs.
+// It returns an error unless it stopped on a convergence criterion.
+func (l *LBFGS) Minimize(o Objective, x0 []float64) ([]float64, error) {
+	if l.M < 1 {
+		return x0, errors.New("L-BFGS history size M should be at least 1")
+	}
+	start := time.Now()
+	l.X = make([]float64, len(x0))
+	copy(l.X, x0)
+	l.Iterations = 0
+	l.Path = nil
+
+	var ss, ys [][]float64
+	f := o.F(l.X)
+	g := o.Grad(l.X)
+	fPrev := math.NaN()
+	if l.Record {
+		l.Path = append(l.Path, newIterate(l.X, f, g))
+	}
+	for {
+		if l.Reason = l.check(l.Iterations, f, fPrev, norm(g), start); l.Reason != NotStopped {
+			return l.X, stopError("L-BFGS", l.Reason)
+		}
+		p := twoLoopRecursion(g, ss, ys)
+		alpha, fNew, gNew, err := l.LineSearch.Search(o, l.X, p, f, g)
+		if err != nil {
+			l.Reason = LineSearchFailed
+			return l.X, err
+		}
+		xNew := axpy(alpha, p, l.X)
+		s := sub(xNew, l.X)
+		y := sub(gNew, g)
+		if dot(s, y) > 1e-10 {
+			if len(ss) == l.M {
+				ss, ys = ss[1:], ys[1:]
+			}
+			ss = append(ss, s)
+			ys = append(ys, y)
+		}
+		fPrev = f
+		l.X, f, g = xNew, fNew, gNew
+		l.Iterations++
+		if l.Record {
+			l.Path = append(l.Path, newIterate(l.X, f, g))
+		}
+	}
+}
+
+// twoLoopRecursion returns the search direction -H*g where H is the implicit
+// inverse Hessian approximation defined by the pairs (ss[i], ys[i]).
+func twoLoopRecursion(g []float64, ss, ys [][]float64) []float64 {
+	q := make([]float64, len(g))
+	copy(q, g)
+	k := len(ss)
+	alphas := make([]float64, k)
+	for i := k - 1; i >= 0; i-- {
+		alphas[i] = dot(ss[i], q) / dot(ys[i], ss[i])
+		q = axpy(-alphas[i], ys[i], q)
+	}
+	if k > 0 {
+		gamma := dot(ss[k-1], ys[k-1]) / dot(ys[k-1], ys[k-1])
+		for i := range q {
+			q[i] *= gamma
+		}
+	}
+	for i := 0; i < k; i++ {
+		beta := dot(ys[i], q) / dot(ys[i], ss[i])
+		q = axpy(alphas[i]-beta, ss[i], q)
+	}
+	for i := range q {
+		q[i] = -q[i]
+	}
+	return q
+}
+
+func identity(n int) [][]float64 {
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		m[i][i] = float64(1)
+	}
+	return m
+}
+
+func matVec(m [][]float64, v []float64) []float64 {
+	ret := make([]float64, len(m))
+	for i := range m {
+		ret[i] = dot(m[i], v)
+	}
+	return ret
+}
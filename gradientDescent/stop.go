@@ -0,0 +1,112 @@
+package GradientDescent
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// DefaultIterationLimit is the number of iterations used when no IterationLimit is set,
+// so that a descent always terminates.
+const DefaultIterationLimit = 100000
+
+// StopReason tells why a descent method stopped.
+type StopReason int
+
+const (
+	NotStopped            StopReason = iota // the descent is still running.
+	ErrorLimitReached                       // the error fell under ErrorLimit.
+	GradientLimitReached                    // the norm of the gradient fell under GradientLimit.
+	RelativeChangeReached                   // the relative change of the error fell under RelativeChange.
+	IterationLimitReached                   // IterationLimit iterations were done.
+	TimeoutReached                          // the descent ran for more than Timeout.
+	LineSearchFailed                        // the line search could not find a step that decreases the error.
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case NotStopped:
+		return "not stopped"
+	case ErrorLimitReached:
+		return "error limit reached"
+	case GradientLimitReached:
+		return "gradient limit reached"
+	case RelativeChangeReached:
+		return "relative change of error limit reached"
+	case IterationLimitReached:
+		return "iteration limit reached"
+	case TimeoutReached:
+		return "timeout reached"
+	case LineSearchFailed:
+		return "line search failed"
+	}
+	return fmt.Sprintf("StopReason(%d)", int(r))
+}
+
+// StoppingCriteria holds the conditions that stop a descent method.
+// The criteria are combined: the descent stops as soon as one of them is met.
+// A zero value disables the corresponding criterion, except for IterationLimit
+// which falls back to DefaultIterationLimit.
+type StoppingCriteria struct {
+	ErrorLimit     float64       // stop when the error falls under this limit.
+	GradientLimit  float64       // stop when the norm of the gradient falls under this limit.
+	RelativeChange float64       // stop when |E(t-1) - E(t)| / |E(t-1)| falls under this limit.
+	IterationLimit int           // stop after this number of iterations.
+	Timeout        time.Duration // stop when the descent runs for longer than this duration.
+}
+
+// Result holds the outcome of a descent method and explains why it stopped.
+type Result struct {
+	X            []float64     // point the descent stopped at.
+	Err          float64       // error at X.
+	GradientNorm float64       // norm of the gradient at X.
+	Iterations   int           // number of iterations done.
+	Elapsed      time.Duration // time taken by the descent.
+	Reason       StopReason    // why the descent stopped.
+}
+
+// String returns a one line summary of the result.
+func (r Result) String() string {
+	return fmt.Sprintf("stopped after %d iterations (%v): error = %v, |gradient| = %v, x = %v", r.Iterations, r.Reason, r.Err, r.GradientNorm, r.X)
+}
+
+// check returns the first criterion met with respect to the current iteration, error,
+// previous error, norm of the gradient and start time of the descent. NotStopped otherwise.
+func (sc *StoppingCriteria) check(iteration int, err, errPrev, gradientNorm float64, start time.Time) StopReason {
+	if sc.ErrorLimit > 0 && err < sc.ErrorLimit {
+		return ErrorLimitReached
+	}
+	if sc.GradientLimit > 0 && gradientNorm < sc.GradientLimit {
+		return GradientLimitReached
+	}
+	if sc.RelativeChange > 0 && !math.IsNaN(errPrev) {
+		if math.Abs(errPrev-err) <= sc.RelativeChange*math.Max(math.Abs(errPrev), math.SmallestNonzeroFloat64) {
+			return RelativeChangeReached
+		}
+	}
+	limit := sc.IterationLimit
+	if limit <= 0 {
+		limit = DefaultIterationLimit
+	}
+	if iteration >= limit {
+		return IterationLimitReached
+	}
+	if sc.Timeout > 0 && time.Since(start) > sc.Timeout {
+		return TimeoutReached
+	}
+	return NotStopped
+}
+
+// converged tells if the reason is one of the convergence criteria
+// rather than a limit on the iterations or the time, or a failure.
+func (r StopReason) converged() bool {
+	return r == ErrorLimitReached || r == GradientLimitReached || r == RelativeChangeReached
+}
+
+// stopError returns nil when method stopped because it converged, an error naming the reason otherwise.
+func stopError(method string, reason StopReason) error {
+	if reason.converged() {
+		return nil
+	}
+	return fmt.Errorf("%s stopped: %v", method, reason)
+}
@@ -0,0 +1,239 @@
+package GradientDescent
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Iterate is a point visited by a descent method.
+type Iterate struct {
+	U            float64
+	V            float64
+	Err          float64   // error at (U, V).
+	GradientNorm float64   // norm of the gradient at (U, V).
+	X            []float64 // full point visited by a Minimizer, U and V are its first two coordinates.
+}
+
+// newIterate returns the Iterate of a Minimizer at point x with error f and gradient g.
+func newIterate(x []float64, f float64, g []float64) Iterate {
+	it := Iterate{Err: f, GradientNorm: norm(g)}
+	it.X = make([]float64, len(x))
+	copy(it.X, x)
+	if len(x) > 0 {
+		it.U = x[0]
+	}
+	if len(x) > 1 {
+		it.V = x[1]
+	}
+	return it
+}
+
+// Trajectory is the sequence of iterates visited by a descent method.
+type Trajectory []Iterate
+
+// dimension returns the number of coordinates of the points of the trajectory, at least 2.
+func (t Trajectory) dimension() int {
+	d := 2
+	for _, it := range t {
+		if len(it.X) > d {
+			d = len(it.X)
+		}
+	}
+	return d
+}
+
+// WriteCSV writes the trajectory to w with the header:
+// iteration,u,v,error,gradient_norm
+// When the points have more than two coordinates the header is:
+// iteration,x1,...,xd,error,gradient_norm
+func (t Trajectory) WriteCSV(w io.Writer) error {
+	d := t.dimension()
+	header := "iteration,u,v,error,gradient_norm"
+	if d > 2 {
+		header = "iteration"
+		for k := 1; k <= d; k++ {
+			header += fmt.Sprintf(",x%d", k)
+		}
+		header += ",error,gradient_norm"
+	}
+	if _, err := fmt.Fprintln(w, header); err != nil {
+		return err
+	}
+	for i, it := range t {
+		x := []float64{it.U, it.V}
+		if d > 2 {
+			x = make([]float64, d)
+			copy(x, it.X)
+		}
+		line := fmt.Sprintf("%d", i)
+		for _, xk := range x {
+			line += fmt.Sprintf(",%g", xk)
+		}
+		if _, err := fmt.Fprintf(w, "%s,%g,%g\n", line, it.Err, it.GradientNorm); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Contour holds the values of an objective of (u, v) evaluated on a N x N grid
+// over [UMin : UMax] x [VMin : VMax].
+type Contour struct {
+	UMin float64
+	UMax float64
+	VMin float64
+	VMax float64
+	N    int         // number of grid points on each axis.
+	Z    [][]float64 // Z[i][j] is the objective at (u_j, v_i).
+}
+
+// NewContour evaluates objective o on a n x n grid over [uMin : uMax] x [vMin : vMax].
+func NewContour(o Objective, uMin, uMax, vMin, vMax float64, n int) (*Contour, error) {
+	if n < 2 {
+		return nil, errors.New("contour grid should have at least 2 points on each axis")
+	}
+	if uMin >= uMax || vMin >= vMax {
+		return nil, errors.New("contour grid should have uMin < uMax and vMin < vMax")
+	}
+	c := Contour{UMin: uMin, UMax: uMax, VMin: vMin, VMax: vMax, N: n}
+	c.Z = make([][]float64, n)
+	for i := 0; i < n; i++ {
+		c.Z[i] = make([]float64, n)
+		for j := 0; j < n; j++ {
+			c.Z[i][j] = o.F([]float64{c.u(j), c.v(i)})
+		}
+	}
+	return &c, nil
+}
+
+// u returns the u coordinate of column j of the grid.
+func (c *Contour) u(j int) float64 {
+	return c.UMin + (c.UMax-c.UMin)*float64(j)/float64(c.N-1)
+}
+
+// v returns the v coordinate of row i of the grid.
+func (c *Contour) v(i int) float64 {
+	return c.VMin + (c.VMax-c.VMin)*float64(i)/float64(c.N-1)
+}
+
+// WriteCSV writes the grid to w with the header:
+// u,v,error
+func (c *Contour) WriteCSV(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "u,v,error"); err != nil {
+		return err
+	}
+	for i := range c.Z {
+		for j := range c.Z[i] {
+			if _, err := fmt.Fprintf(w, "%g,%g,%g\n", c.u(j), c.v(i), c.Z[i][j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// svgSize is the width and height in pixels of the svg written by WriteSVG.
+const svgSize = 500
+
+// pathColors are the colors used in turn for the trajectories drawn by WriteSVG.
+var pathColors = []string{"#d62728", "#1f77b4", "#2ca02c", "#ff7f0e", "#9467bd"}
+
+// WriteSVG writes to w an svg image of the contour lines of the grid with the given trajectories drawn on top.
+// The contour levels are spaced logarithmically between the smallest and largest positive values of the grid.
+func (c *Contour) WriteSVG(w io.Writer, paths ...Trajectory) error {
+	x := func(u float64) float64 { return (u - c.UMin) / (c.UMax - c.UMin) * svgSize }
+	y := func(v float64) float64 { return svgSize - (v-c.VMin)/(c.VMax-c.VMin)*svgSize }
+
+	if _, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\">\n", svgSize, svgSize); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "<rect width=\"%d\" height=\"%d\" fill=\"white\" stroke=\"black\"/>\n", svgSize, svgSize); err != nil {
+		return err
+	}
+
+	for _, level := range c.levels(15) {
+		for _, s := range c.isoline(level) {
+			if _, err := fmt.Fprintf(w, "<line x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\" stroke=\"gray\" stroke-width=\"0.5\"/>\n",
+				x(s[0]), y(s[1]), x(s[2]), y(s[3])); err != nil {
+				return err
+			}
+		}
+	}
+
+	for k, path := range paths {
+		color := pathColors[k%len(pathColors)]
+		if _, err := fmt.Fprintf(w, "<polyline fill=\"none\" stroke=\"%s\" stroke-width=\"1.5\" points=\"", color); err != nil {
+			return err
+		}
+		for _, it := range path {
+			if _, err := fmt.Fprintf(w, "%.2f,%.2f ", x(it.U), y(it.V)); err != nil {
+				return err
+			}
+		}
+		if _, err := fmt.Fprintln(w, "\"/>"); err != nil {
+			return err
+		}
+		for _, it := range path {
+			if _, err := fmt.Fprintf(w, "<circle cx=\"%.2f\" cy=\"%.2f\" r=\"2\" fill=\"%s\"/>\n", x(it.U), y(it.V), color); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := fmt.Fprintln(w, "</svg>")
+	return err
+}
+
+// levels returns n contour levels spaced logarithmically between the smallest
+// and the largest positive values of the grid.
+func (c *Contour) levels(n int) []float64 {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for i := range c.Z {
+		for _, z := range c.Z[i] {
+			if z > 0 && !math.IsInf(z, 0) {
+				lo = math.Min(lo, z)
+				hi = math.Max(hi, z)
+			}
+		}
+	}
+	if math.IsInf(lo, 1) || lo == hi {
+		return nil
+	}
+	levels := make([]float64, n)
+	for k := range levels {
+		levels[k] = lo * math.Pow(hi/lo, (float64(k)+0.5)/float64(n))
+	}
+	return levels
+}
+
+// isoline returns the segments (u1, v1, u2, v2) of the contour line at the given level,
+// computed with the marching squares algorithm.
+func (c *Contour) isoline(level float64) [][4]float64 {
+	var segments [][4]float64
+	// interpolate returns the point between grid points a and b where the objective crosses level.
+	interpolate := func(ia, ja, ib, jb int) [2]float64 {
+		za, zb := c.Z[ia][ja], c.Z[ib][jb]
+		t := (level - za) / (zb - za)
+		return [2]float64{c.u(ja) + t*(c.u(jb)-c.u(ja)), c.v(ia) + t*(c.v(ib)-c.v(ia))}
+	}
+	for i := 0; i < c.N-1; i++ {
+		for j := 0; j < c.N-1; j++ {
+			// corners of the cell in counter clockwise order.
+			corners := [4][2]int{{i, j}, {i, j + 1}, {i + 1, j + 1}, {i + 1, j}}
+			var crossings [][2]float64
+			for k := 0; k < 4; k++ {
+				a, b := corners[k], corners[(k+1)%4]
+				za, zb := c.Z[a[0]][a[1]], c.Z[b[0]][b[1]]
+				if (za < level) != (zb < level) {
+					crossings = append(crossings, interpolate(a[0], a[1], b[0], b[1]))
+				}
+			}
+			for k := 0; k+1 < len(crossings); k += 2 {
+				p, q := crossings[k], crossings[k+1]
+				segments = append(segments, [4]float64{p[0], p[1], q[0], q[1]})
+			}
+		}
+	}
+	return segments
+}
@@ -0,0 +1,110 @@
+package GradientDescent
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"strconv"
+	"testing"
+)
+
+// bowl is the quadratic f(u, v) = u^2 + v^2 with minimum at (0, 0).
+var bowl = Objective{
+	F: func(x []float64) float64 {
+		return x[0]*x[0] + x[1]*x[1]
+	},
+	Grad: func(x []float64) []float64 {
+		return []float64{2 * x[0], 2 * x[1]}
+	},
+}
+
+func TestContourLevels(t *testing.T) {
+	c, err := NewContour(bowl, -1, 1, -1, 1, 41)
+	if err != nil {
+		t.Fatalf("NewContour returned error %v", err)
+	}
+	levels := c.levels(5)
+	if len(levels) != 5 {
+		t.Fatalf("levels(5) returned %d levels, want 5", len(levels))
+	}
+	for k, level := range levels {
+		if level <= 0 || level >= 2 || (k > 0 && level <= levels[k-1]) {
+			t.Errorf("levels(5) == %v, want increasing levels in (0, 2)", levels)
+		}
+		// the isolines of the bowl are circles of radius sqrt(level).
+		segments := c.isoline(level)
+		if len(segments) == 0 {
+			t.Errorf("isoline(%v) is empty", level)
+		}
+		for _, s := range segments {
+			for _, p := range [][2]float64{{s[0], s[1]}, {s[2], s[3]}} {
+				if r2 := p[0]*p[0] + p[1]*p[1]; math.Abs(r2-level) > 0.01 {
+					t.Errorf("isoline(%v) has point %v with u^2 + v^2 = %v", level, p, r2)
+				}
+			}
+		}
+	}
+}
+
+func TestTrajectoryCSV(t *testing.T) {
+	b := NewBFGS()
+	b.Record = true
+	x0 := []float64{-1.2, 1, 0.5}
+	quadratic := Objective{
+		F: func(x []float64) float64 {
+			return x[0]*x[0] + 2*x[1]*x[1] + 3*x[2]*x[2]
+		},
+		Grad: func(x []float64) []float64 {
+			return []float64{2 * x[0], 4 * x[1], 6 * x[2]}
+		},
+	}
+	x, err := b.Minimize(quadratic, x0)
+	if err != nil {
+		t.Fatalf("Minimize returned error %v", err)
+	}
+	if len(b.Path) != b.Iterations+1 {
+		t.Fatalf("Path has %d iterates, want %d", len(b.Path), b.Iterations+1)
+	}
+	if first, last := b.Path[0], b.Path[len(b.Path)-1]; first.X[2] != x0[2] || last.X[2] != x[2] {
+		t.Errorf("Path goes from %v to %v, want from %v to %v", first.X, last.X, x0, x)
+	}
+
+	var buf bytes.Buffer
+	if err := b.Path.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV returned error %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("cannot read csv: %v", err)
+	}
+	if len(records) != len(b.Path)+1 || len(records[0]) != 6 || records[0][3] != "x3" {
+		t.Fatalf("csv header %v with %d rows, want x3 column and %d rows", records[0], len(records), len(b.Path)+1)
+	}
+	for i, it := range b.Path {
+		values := append(append([]float64{}, it.X...), it.Err, it.GradientNorm)
+		for k, want := range values {
+			got, err := strconv.ParseFloat(records[i+1][k+1], 64)
+			if err != nil || math.Abs(got-want) > 1e-5*math.Max(1, math.Abs(want)) {
+				t.Errorf("row %d column %d == %v, want %v", i, k+1, records[i+1][k+1], want)
+			}
+		}
+	}
+}
+
+func TestCoordinateDescentPath(t *testing.T) {
+	var cd CoordinateDescent
+	cd.U, cd.V, cd.Eta = 1, 1, 0.1
+	cd.IterationLimit = 5
+	cd.Record = true
+	cd.Descend()
+	if len(cd.Path) != 2*5+1 {
+		t.Fatalf("Path has %d iterates, want %d", len(cd.Path), 2*5+1)
+	}
+	// every step moves a single coordinate.
+	for i := 1; i < len(cd.Path); i++ {
+		p, q := cd.Path[i-1], cd.Path[i]
+		if p.U != q.U && p.V != q.V {
+			t.Errorf("step %d goes from (%v, %v) to (%v, %v), want a single coordinate to move", i, p.U, p.V, q.U, q.V)
+		}
+	}
+}
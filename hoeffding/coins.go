@@ -0,0 +1,168 @@
+package hoeffding
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// CoinExperiment holds the parameters of the coin flip experiment:
+// NCoins coins of bias Mu are flipped NFlips times each, and three coins are looked at:
+// c1 the first coin, cRand a coin chosen at random and cMin the coin with the minimum frequency of heads.
+// The experiment is repeated NRuns times.
+type CoinExperiment struct {
+	NRuns  int     // number of times the experiment is repeated.
+	NCoins int     // number of coins flipped in each run.
+	NFlips int     // number of flips of each coin.
+	Mu     float64 // probability of heads of every coin.
+	Seed   int64   // when not 0, the runs are done on a single source seeded with Seed so that results are reproducible.
+}
+
+// NewCoinExperiment is a constructor of the coin flip experiment of the course:
+// NRuns = 100000
+// NCoins = 1000
+// NFlips = 10
+// Mu = 0.5
+func NewCoinExperiment() *CoinExperiment {
+	e := CoinExperiment{}
+	e.NRuns = 100000
+	e.NCoins = 1000
+	e.NFlips = 10
+	e.Mu = 0.5
+	return &e
+}
+
+// CoinResult holds the empirical distribution of the frequency of heads ν of c1, cRand and cMin.
+// V1[k] is the number of runs in which c1 got k heads, and similarly for VRand and VMin.
+type CoinResult struct {
+	NRuns  int
+	NFlips int
+	Mu     float64
+	V1     []int
+	VRand  []int
+	VMin   []int
+}
+
+// Run runs the experiment concurrently on all the CPUs available,
+// or on a single goroutine when Seed is set.
+func (e *CoinExperiment) Run() (*CoinResult, error) {
+	if e.NRuns <= 0 || e.NCoins <= 0 || e.NFlips <= 0 {
+		return nil, errors.New("coin experiment needs a positive number of runs, coins and flips")
+	}
+	if e.Seed != 0 {
+		return e.run(e.NRuns, rand.New(rand.NewSource(e.Seed))), nil
+	}
+	workers := runtime.NumCPU()
+	results := make([]*CoinResult, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		runs := e.NRuns / workers
+		if w < e.NRuns%workers {
+			runs++
+		}
+		wg.Add(1)
+		go func(w, runs int) {
+			defer wg.Done()
+			r := rand.New(rand.NewSource(time.Now().UnixNano() + int64(w)))
+			results[w] = e.run(runs, r)
+		}(w, runs)
+	}
+	wg.Wait()
+
+	res := e.newResult()
+	for _, partial := range results {
+		for k := 0; k <= e.NFlips; k++ {
+			res.V1[k] += partial.V1[k]
+			res.VRand[k] += partial.VRand[k]
+			res.VMin[k] += partial.VMin[k]
+		}
+	}
+	res.NRuns = e.NRuns
+	return res, nil
+}
+
+// run runs the experiment runs times using the random source r.
+func (e *CoinExperiment) run(runs int, r *rand.Rand) *CoinResult {
+	res := e.newResult()
+	heads := make([]int, e.NCoins)
+	for run := 0; run < runs; run++ {
+		for i := range heads {
+			heads[i] = 0
+			for j := 0; j < e.NFlips; j++ {
+				if r.Float64() < e.Mu {
+					heads[i]++
+				}
+			}
+		}
+		min := heads[0]
+		for _, h := range heads {
+			if h < min {
+				min = h
+			}
+		}
+		res.V1[heads[0]]++
+		res.VRand[heads[r.Intn(e.NCoins)]]++
+		res.VMin[min]++
+	}
+	res.NRuns = runs
+	return res
+}
+
+func (e *CoinExperiment) newResult() *CoinResult {
+	return &CoinResult{
+		NFlips: e.NFlips,
+		Mu:     e.Mu,
+		V1:     make([]int, e.NFlips+1),
+		VRand:  make([]int, e.NFlips+1),
+		VMin:   make([]int, e.NFlips+1),
+	}
+}
+
+// Average returns the average frequency of heads given its distribution.
+func (res *CoinResult) Average(distribution []int) float64 {
+	sum := float64(0)
+	for k, count := range distribution {
+		sum += float64(k) / float64(res.NFlips) * float64(count)
+	}
+	return sum / float64(res.NRuns)
+}
+
+// Deviation returns the empirical probability P[|ν - μ| > eps] given the distribution of ν.
+func (res *CoinResult) Deviation(distribution []int, eps float64) float64 {
+	count := 0
+	for k, c := range distribution {
+		if math.Abs(float64(k)/float64(res.NFlips)-res.Mu) > eps {
+			count += c
+		}
+	}
+	return float64(count) / float64(res.NRuns)
+}
+
+// DeviationRow compares the empirical probability P[|ν - μ| > E] of each coin with the Hoeffding bound.
+type DeviationRow struct {
+	E         float64
+	V1        float64 // empirical probability for c1.
+	VRand     float64 // empirical probability for cRand.
+	VMin      float64 // empirical probability for cMin.
+	Hoeffding float64 // Hoeffding bound for a single coin: 2exp(-2(E^2)NFlips)
+}
+
+// Deviations returns a DeviationRow for each epsilon in es.
+func (res *CoinResult) Deviations(es []float64) []DeviationRow {
+	rows := make([]DeviationRow, len(es))
+	hoef := HoeffdingInequality{M: 1, N: res.NFlips}
+	for i, eps := range es {
+		hoef.E = eps
+		rows[i] = DeviationRow{
+			E:         eps,
+			V1:        res.Deviation(res.V1, eps),
+			VRand:     res.Deviation(res.VRand, eps),
+			VMin:      res.Deviation(res.VMin, eps),
+			Hoeffding: hoef.Compute(),
+		}
+	}
+	return rows
+}
@@ -0,0 +1,53 @@
+package hoeffding
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCoinExperiment(t *testing.T) {
+	e := NewCoinExperiment()
+	e.NRuns = 2000
+	e.NCoins = 100
+	e.Seed = 1
+	res, err := e.Run()
+	if err != nil {
+		t.Fatalf("Run returned error %v", err)
+	}
+	again, _ := e.Run()
+	if !reflect.DeepEqual(res, again) {
+		t.Errorf("Run with a seed should be reproducible")
+	}
+
+	v1, vRand, vMin := res.Average(res.V1), res.Average(res.VRand), res.Average(res.VMin)
+	if vMin > v1 || vMin > vRand {
+		t.Errorf("average of ν_min == %v should be at most ν_1 == %v and ν_rand == %v", vMin, v1, vRand)
+	}
+	if math.Abs(vRand-0.5) > 0.02 || math.Abs(v1-0.5) > 0.02 {
+		t.Errorf("averages of ν_1 == %v and ν_rand == %v, want close to 0.5", v1, vRand)
+	}
+
+	e.NRuns = 0
+	if _, err := e.Run(); err == nil {
+		t.Errorf("Run with no runs should return an error")
+	}
+}
+
+func TestDeviations(t *testing.T) {
+	// 4 runs of 2 flips: c1 got 0, 1, 1 and 2 heads.
+	res := &CoinResult{NRuns: 4, NFlips: 2, Mu: 0.5, V1: []int{1, 2, 1}, VRand: []int{0, 4, 0}, VMin: []int{4, 0, 0}}
+	rows := res.Deviations([]float64{0.25, 0.5})
+	if rows[0].V1 != 0.5 || rows[0].VRand != 0 || rows[0].VMin != 1 {
+		t.Errorf("Deviations(0.25) == %+v, want V1 = 0.5, VRand = 0, VMin = 1", rows[0])
+	}
+	if rows[1].V1 != 0 || rows[1].VMin != 0 {
+		t.Errorf("Deviations(0.5) == %+v, want no deviation strictly greater than 0.5", rows[1])
+	}
+	if want := 2 * math.Exp(-2*0.25*0.25*2); math.Abs(rows[0].Hoeffding-want) > 1e-12 {
+		t.Errorf("Deviations(0.25).Hoeffding == %v, want %v", rows[0].Hoeffding, want)
+	}
+	if res.Average(res.V1) != 0.5 {
+		t.Errorf("Average(V1) == %v, want 0.5", res.Average(res.V1))
+	}
+}
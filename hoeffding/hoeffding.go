@@ -8,13 +8,15 @@ type HoeffdingInequality struct {
 	M     int     // number of hypothesis
 	N     int     // number of examples
 	E     float64 // epsilon
-	Bound float64 // bound for 2Mexp(-2(E^2)N)
+	Bound float64 // bound for 2Mexp(-2(E^2)N), target probability of the inverse solvers.
+	Mu    float64 // mean μ of the bernoulli variables, used by the inequalities depending on the variance.
 }
 
 func NewHoeffdingExperiment() HoeffdingInequality {
 	h := HoeffdingInequality{}
 	h.E = 0.05
 	h.Bound = 0.03
+	h.Mu = 0.5
 	return h
 }
 
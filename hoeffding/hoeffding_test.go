@@ -0,0 +1,76 @@
+package hoeffding
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSolveN(t *testing.T) {
+	hoef := NewHoeffdingExperiment()
+	want := map[int]int{1: 840, 10: 1301, 100: 1761}
+	for m, n := range want {
+		hoef.M = m
+		if got, err := hoef.SolveN(InequalityHoeffding); err != nil || got != n {
+			t.Errorf("SolveN(Hoeffding) with M = %d == %v, %v, want %v", m, got, err, n)
+		}
+	}
+
+	// the exact tail is never looser than Hoeffding.
+	hoef.M = 1
+	hoeffdingN, _ := hoef.SolveN(InequalityHoeffding)
+	binomialN, err := hoef.SolveN(InequalityBinomialTail)
+	if err != nil || binomialN > hoeffdingN {
+		t.Errorf("SolveN(BinomialTail) == %v, %v, want at most %v", binomialN, err, hoeffdingN)
+	}
+}
+
+func TestSolveE(t *testing.T) {
+	hoef := NewHoeffdingExperiment()
+	hoef.M = 1
+	hoef.N = 1000
+	for _, i := range Inequalities {
+		e, err := hoef.SolveE(i)
+		if err != nil {
+			t.Errorf("SolveE(%v) returned error %v", i, err)
+			continue
+		}
+		hoef.E = e
+		if p := hoef.Probability(i); p > hoef.Bound {
+			t.Errorf("%v with E = SolveE() == %v, want at most %v", i, p, hoef.Bound)
+		}
+	}
+	hoef.E = 0.05
+	want := math.Sqrt(math.Log(2/hoef.Bound) / (2 * float64(hoef.N)))
+	if got, _ := hoef.SolveE(InequalityHoeffding); math.Abs(got-want) > 1e-8 {
+		t.Errorf("SolveE(Hoeffding) == %v, want %v", got, want)
+	}
+}
+
+func TestInequalities(t *testing.T) {
+	hoef := NewHoeffdingExperiment()
+	hoef.M = 1
+	hoef.N = 100
+	hoef.E = 0.1
+	want := map[Inequality]float64{
+		InequalityHoeffding:    2 * math.Exp(-2),
+		InequalityChernoff:     math.Exp(-1/1.1) + math.Exp(-1),
+		InequalityBernstein:    0.34247428588957624,
+		InequalityBennett:      0.3384492577275087,
+		InequalityBinomialTail: 0.035200200217704807,
+	}
+	for i, p := range want {
+		if got := hoef.Probability(i); math.Abs(got-p) > 1e-12 {
+			t.Errorf("%v == %v, want %v", i, got, p)
+		}
+	}
+
+	// with μ = 0, ν never falls under μ and only the upper tail of Chernoff is left.
+	hoef.Mu = 0
+	if got, want := hoef.Chernoff(), math.Exp(-10); math.Abs(got-want) > 1e-12 {
+		t.Errorf("Chernoff() with μ = 0 == %v, want %v", got, want)
+	}
+	hoef.E = 0
+	if got := hoef.Chernoff(); got != 1 {
+		t.Errorf("Chernoff() with μ = 0 and E = 0 == %v, want 1", got)
+	}
+}
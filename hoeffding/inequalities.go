@@ -0,0 +1,185 @@
+package hoeffding
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Inequality identifies a concentration inequality bounding P[|ν - μ| > E] for the
+// frequency ν of N bernoulli variables of mean μ, with a union bound over M hypothesis.
+type Inequality int
+
+const (
+	InequalityHoeffding    Inequality = iota // 2M exp(-2E²N)
+	InequalityChernoff                       // multiplicative Chernoff bound.
+	InequalityBernstein                      // Bernstein inequality.
+	InequalityBennett                        // Bennett inequality.
+	InequalityBinomialTail                   // exact tail of the binomial distribution.
+)
+
+// Inequalities lists all the concentration inequalities.
+var Inequalities = []Inequality{InequalityHoeffding, InequalityChernoff, InequalityBernstein, InequalityBennett, InequalityBinomialTail}
+
+func (i Inequality) String() string {
+	switch i {
+	case InequalityHoeffding:
+		return "Hoeffding"
+	case InequalityChernoff:
+		return "Chernoff"
+	case InequalityBernstein:
+		return "Bernstein"
+	case InequalityBennett:
+		return "Bennett"
+	case InequalityBinomialTail:
+		return "Binomial tail"
+	}
+	return fmt.Sprintf("Inequality(%d)", int(i))
+}
+
+// Probability returns the bound on P[|ν - μ| > E] given by inequality i.
+func (hoef *HoeffdingInequality) Probability(i Inequality) float64 {
+	switch i {
+	case InequalityHoeffding:
+		return hoef.Compute()
+	case InequalityChernoff:
+		return hoef.Chernoff()
+	case InequalityBernstein:
+		return hoef.Bernstein()
+	case InequalityBennett:
+		return hoef.Bennett()
+	case InequalityBinomialTail:
+		return hoef.BinomialTail()
+	}
+	return math.NaN()
+}
+
+// Chernoff is the multiplicative Chernoff bound with δ = E/μ:
+// P[ν >= (1 + δ)μ] <= exp(-δ²μN/(2 + δ))
+// P[ν <= (1 - δ)μ] <= exp(-δ²μN/2)
+// Both tails are added and multiplied by M.
+// The lower tail is 0 when μ <= 0, as ν is never negative, and each tail is at most 1 when E = 0.
+func (hoef *HoeffdingInequality) Chernoff() float64 {
+	n := float64(hoef.N)
+	e := hoef.E
+	upper, lower := float64(1), float64(0)
+	if e > 0 {
+		upper = math.Exp(-e * e * n / (float64(2)*math.Max(hoef.Mu, 0) + e))
+	}
+	if hoef.Mu > 0 {
+		lower = math.Exp(-e * e * n / (float64(2) * hoef.Mu))
+	}
+	return float64(hoef.M) * (upper + lower)
+}
+
+// Bernstein uses the variance σ² = μ(1 - μ) of the bernoulli variables:
+// 2M exp(-E²N / (2σ² + 2E/3))
+func (hoef *HoeffdingInequality) Bernstein() float64 {
+	n := float64(hoef.N)
+	e := hoef.E
+	return float64(2) * float64(hoef.M) * math.Exp(-e*e*n/(float64(2)*hoef.variance()+float64(2)*e/float64(3)))
+}
+
+// Bennett uses the variance σ² = μ(1 - μ) of the bernoulli variables:
+// 2M exp(-Nσ² h(E/σ²)) with h(u) = (1 + u)ln(1 + u) - u
+func (hoef *HoeffdingInequality) Bennett() float64 {
+	v := hoef.variance()
+	if v == 0 {
+		// ν is constant and equal to μ.
+		return 0
+	}
+	u := hoef.E / v
+	h := (float64(1)+u)*math.Log1p(u) - u
+	return float64(2) * float64(hoef.M) * math.Exp(-float64(hoef.N)*v*h)
+}
+
+// BinomialTail is the exact probability P[|ν - μ| > E] when Nν follows a binomial distribution B(N, μ),
+// multiplied by M.
+func (hoef *HoeffdingInequality) BinomialTail() float64 {
+	n := hoef.N
+	p := float64(0)
+	for k := 0; k <= n; k++ {
+		if math.Abs(float64(k)/float64(n)-hoef.Mu) > hoef.E {
+			p += math.Exp(logBinomialPMF(n, k, hoef.Mu))
+		}
+	}
+	return float64(hoef.M) * p
+}
+
+func (hoef *HoeffdingInequality) variance() float64 {
+	return hoef.Mu * (float64(1) - hoef.Mu)
+}
+
+// logBinomialPMF returns ln(C(n, k) p^k (1 - p)^(n - k)).
+func logBinomialPMF(n, k int, p float64) float64 {
+	if p == 0 || p == 1 {
+		if (p == 0 && k == 0) || (p == 1 && k == n) {
+			return 0
+		}
+		return math.Inf(-1)
+	}
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c + float64(k)*math.Log(p) + float64(n-k)*math.Log1p(-p)
+}
+
+// maxN is the largest number of examples looked at by SolveN.
+const maxN = 1 << 30
+
+// SolveN returns the smallest number of examples N for which inequality i gives
+// a probability of at most Bound, with M and E fixed.
+// It doubles N until the probability is under Bound and then bisects.
+// The binomial tail is not monotone in N, for it the result is a N satisfying Bound close to the smallest one.
+func (hoef *HoeffdingInequality) SolveN(i Inequality) (int, error) {
+	if hoef.Bound <= 0 {
+		return 0, errors.New("bound should be positive")
+	}
+	h := *hoef
+	lo, hi := 0, 1
+	for {
+		h.N = hi
+		if h.Probability(i) <= hoef.Bound {
+			break
+		}
+		if hi >= maxN {
+			return 0, fmt.Errorf("%v does not reach probability %v for N under %d", i, hoef.Bound, maxN)
+		}
+		lo, hi = hi, 2*hi
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		h.N = mid
+		if h.Probability(i) <= hoef.Bound {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
+
+// SolveE returns the smallest tolerance E for which inequality i gives
+// a probability of at most Bound, with M and N fixed.
+// It bisects E in [0 : 1] until the interval is under 1e-9.
+func (hoef *HoeffdingInequality) SolveE(i Inequality) (float64, error) {
+	if hoef.Bound <= 0 {
+		return 0, errors.New("bound should be positive")
+	}
+	h := *hoef
+	lo, hi := float64(0), float64(1)
+	h.E = hi
+	if h.Probability(i) > hoef.Bound {
+		return 0, fmt.Errorf("%v does not reach probability %v for E <= 1", i, hoef.Bound)
+	}
+	for hi-lo > 1e-9 {
+		mid := (lo + hi) / float64(2)
+		h.E = mid
+		if h.Probability(i) <= hoef.Bound {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+	return hi, nil
+}
@@ -0,0 +1,141 @@
+package learningCurve
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+
+	"github.com/santiaago/caltechx.go/linreg"
+	"github.com/santiaago/caltechx.go/logreg"
+	"github.com/santiaago/caltechx.go/model"
+	"github.com/santiaago/caltechx.go/pla"
+)
+
+// Trial generates a data set of n points, learns on it and returns
+// the in sample error and the out of sample error of the learned hypothesis.
+type Trial func(n int) (ein float64, eout float64, err error)
+
+// Point holds the mean and the standard error of Ein and Eout over Trials runs with N training points.
+type Point struct {
+	N          int
+	Trials     int
+	Ein        float64
+	EinStdErr  float64
+	Eout       float64
+	EoutStdErr float64
+}
+
+// Generate runs trial the given number of times for every number of training points in ns
+// and returns a point of the learning curve for each of them.
+func Generate(trial Trial, ns []int, trials int) ([]Point, error) {
+	if trials < 2 {
+		return nil, errors.New("learning curve needs at least 2 trials to compute standard errors")
+	}
+	points := make([]Point, len(ns))
+	for i, n := range ns {
+		eins := make([]float64, trials)
+		eouts := make([]float64, trials)
+		for t := 0; t < trials; t++ {
+			ein, eout, err := trial(n)
+			if err != nil {
+				return nil, fmt.Errorf("trial with N = %d: %v", n, err)
+			}
+			eins[t], eouts[t] = ein, eout
+		}
+		points[i] = Point{N: n, Trials: trials}
+		points[i].Ein, points[i].EinStdErr = meanAndStdErr(eins)
+		points[i].Eout, points[i].EoutStdErr = meanAndStdErr(eouts)
+	}
+	return points, nil
+}
+
+// meanAndStdErr returns the mean of values and its standard error s/sqrt(n),
+// with s the sample standard deviation.
+func meanAndStdErr(values []float64) (float64, float64) {
+	n := float64(len(values))
+	mean := float64(0)
+	for _, v := range values {
+		mean += v
+	}
+	mean /= n
+	ss := float64(0)
+	for _, v := range values {
+		ss += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(ss/(n-1)) / math.Sqrt(n)
+}
+
+// WriteCSV writes the points of a learning curve to w with the header:
+// n,trials,ein,ein_stderr,eout,eout_stderr
+func WriteCSV(w io.Writer, points []Point) error {
+	if _, err := fmt.Fprintln(w, "n,trials,ein,ein_stderr,eout,eout_stderr"); err != nil {
+		return err
+	}
+	for _, p := range points {
+		if _, err := fmt.Fprintf(w, "%d,%d,%g,%g,%g,%g\n", p.N, p.Trials, p.Ein, p.EinStdErr, p.Eout, p.EoutStdErr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LinearTarget returns a generator of data sets labeled by a random line through two points of [-1 : 1]²:
+// y = +1 when x2 >= f(x1), -1 otherwise,
+// with n training examples and testSize test examples drawn uniformly in [-1 : 1]².
+func LinearTarget(testSize int) func(n int) (train, test model.Dataset) {
+	return func(n int) (model.Dataset, model.Dataset) {
+		x1, y1, x2, y2 := uniform(), uniform(), uniform(), uniform()
+		slope := (y2 - y1) / (x2 - x1)
+		f := func(x float64) float64 { return y1 + slope*(x-x1) }
+		draw := func(size int) model.Dataset {
+			d := model.Dataset{X: make([][]float64, size), Y: make([]int, size)}
+			for i := range d.X {
+				d.X[i] = []float64{uniform(), uniform()}
+				d.Y[i] = 1
+				if d.X[i][1] < f(d.X[i][0]) {
+					d.Y[i] = -1
+				}
+			}
+			return d
+		}
+		return draw(n), draw(testSize)
+	}
+}
+
+// uniform returns a random float number in [-1 : 1].
+func uniform() float64 {
+	return 2*rand.Float64() - 1
+}
+
+// PLATrial returns a Trial that learns the data sets of generate with the perceptron learning algorithm.
+func PLATrial(generate func(n int) (train, test model.Dataset)) Trial {
+	return FitTrial(generate, func() model.Learner { return pla.NewPLA() })
+}
+
+// LinearRegressionTrial returns a Trial that learns the data sets of generate with linear regression used for classification.
+func LinearRegressionTrial(generate func(n int) (train, test model.Dataset)) Trial {
+	return FitTrial(generate, func() model.Learner { return linreg.NewLinearRegression() })
+}
+
+// LogisticRegressionTrial returns a Trial that learns the data sets of generate with logistic regression.
+// Ein and Eout are classification errors like those of every other trial,
+// the cross entropy errors are given by the Ein and Eout of the logreg package.
+func LogisticRegressionTrial(generate func(n int) (train, test model.Dataset)) Trial {
+	return FitTrial(generate, func() model.Learner { return logreg.NewLogisticRegression() })
+}
+
+// FitTrial returns a Trial that draws a training and a test set of n examples with generate,
+// fits a fresh learner on the training set and scores it on both sets.
+// The trial returns the error of Fit when the learner fails to train.
+func FitTrial(generate func(n int) (train, test model.Dataset), newLearner func() model.Learner) Trial {
+	return func(n int) (float64, float64, error) {
+		train, test := generate(n)
+		l := newLearner()
+		if err := l.Fit(train); err != nil {
+			return 0, 0, err
+		}
+		return l.Score(train), l.Score(test), nil
+	}
+}
@@ -0,0 +1,138 @@
+package learningCurve
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/santiaago/caltechx.go/model"
+)
+
+func TestGenerate(t *testing.T) {
+	// the trial alternates between Ein = 0 and Ein = 2, Eout is always 1/n.
+	calls := 0
+	trial := func(n int) (float64, float64, error) {
+		calls++
+		return float64(2 * (calls % 2)), float64(1) / float64(n), nil
+	}
+	points, err := Generate(trial, []int{10, 100}, 4)
+	if err != nil {
+		t.Fatalf("Generate returned error %v", err)
+	}
+	if len(points) != 2 || calls != 8 {
+		t.Fatalf("Generate returned %d points after %d trials, want 2 points after 8 trials", len(points), calls)
+	}
+	// values 0, 2, 0, 2 have a sample standard deviation of sqrt(4/3).
+	wantStdErr := math.Sqrt(float64(4)/3) / 2
+	for i, n := range []int{10, 100} {
+		p := points[i]
+		if p.N != n || p.Trials != 4 || p.Ein != 1 || math.Abs(p.EinStdErr-wantStdErr) > 1e-12 {
+			t.Errorf("point %+v, want N = %d, Ein = 1 and standard error %v", p, n, wantStdErr)
+		}
+		if p.Eout != float64(1)/float64(n) || p.EoutStdErr != 0 {
+			t.Errorf("point %+v, want Eout = %v without error", p, float64(1)/float64(n))
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, points); err != nil {
+		t.Fatalf("WriteCSV returned error %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[2], "100,4,1,") {
+		t.Errorf("WriteCSV wrote:\n%s", buf.String())
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	ok := func(n int) (float64, float64, error) { return 0, 0, nil }
+	if _, err := Generate(ok, []int{10}, 1); err == nil {
+		t.Errorf("Generate with a single trial should return an error")
+	}
+	failing := func(n int) (float64, float64, error) { return 0, 0, errors.New("failed") }
+	if _, err := Generate(failing, []int{10}, 2); err == nil {
+		t.Errorf("Generate should return the error of the trial")
+	}
+}
+
+// constant is a learner that predicts the most frequent label of the data set it was fitted on.
+type constant struct {
+	y float64
+}
+
+func (c *constant) Fit(d model.Dataset) error {
+	sum := 0
+	for _, y := range d.Y {
+		sum += y
+	}
+	c.y = 1
+	if sum < 0 {
+		c.y = -1
+	}
+	return nil
+}
+
+func (c *constant) Predict(x []float64) float64   { return c.y }
+func (c *constant) Score(d model.Dataset) float64 { return model.ClassificationError(c, d) }
+func (c *constant) Weights() []float64            { return []float64{c.y} }
+
+func TestFitTrial(t *testing.T) {
+	generate := func(n int) (model.Dataset, model.Dataset) {
+		train := model.Dataset{X: make([][]float64, n), Y: make([]int, n)}
+		for i := range train.Y {
+			train.X[i] = []float64{float64(i)}
+			train.Y[i] = -1
+		}
+		train.Y[0] = 1
+		test := model.Dataset{X: [][]float64{{0}, {1}}, Y: []int{1, -1}}
+		return train, test
+	}
+	trial := FitTrial(generate, func() model.Learner { return &constant{} })
+	ein, eout, err := trial(4)
+	if err != nil || ein != 0.25 || eout != 0.5 {
+		t.Errorf("trial(4) == %v, %v, %v, want 0.25, 0.5", ein, eout, err)
+	}
+}
+
+func TestTrials(t *testing.T) {
+	empty := func(n int) (model.Dataset, model.Dataset) { return model.Dataset{}, model.Dataset{} }
+	trials := []struct {
+		name  string
+		trial func(generate func(n int) (train, test model.Dataset)) Trial
+	}{
+		{"PLA", PLATrial},
+		{"linear regression", LinearRegressionTrial},
+		{"logistic regression", LogisticRegressionTrial},
+	}
+	for _, tt := range trials {
+		ein, eout, err := tt.trial(LinearTarget(100))(20)
+		if err != nil {
+			t.Errorf("%s: trial returned error %v", tt.name, err)
+			continue
+		}
+		// classification errors are fractions of the 20 training and the 100 test examples.
+		if ein < 0 || ein > 1 || math.Abs(ein*20-math.Round(ein*20)) > 1e-9 {
+			t.Errorf("%s: Ein == %v, want a fraction of the 20 training examples", tt.name, ein)
+		}
+		if eout < 0 || eout > 1 || math.Abs(eout*100-math.Round(eout*100)) > 1e-9 {
+			t.Errorf("%s: Eout == %v, want a fraction of the 100 test examples", tt.name, eout)
+		}
+		if _, _, err := tt.trial(empty)(20); err == nil {
+			t.Errorf("%s: trial on an empty data set should return an error", tt.name)
+		}
+	}
+}
+
+func TestLinearTarget(t *testing.T) {
+	train, test := LinearTarget(30)(10)
+	if train.Len() != 10 || test.Len() != 30 {
+		t.Fatalf("LinearTarget(30)(10) made %d training and %d test examples, want 10 and 30", train.Len(), test.Len())
+	}
+	// the perceptron separates the examples of a linear target.
+	ein, _, err := PLATrial(func(n int) (model.Dataset, model.Dataset) { return train, test })(10)
+	if err != nil || ein != 0 {
+		t.Errorf("PLA trial == %v, %v, want Ein = 0", ein, err)
+	}
+}
@@ -0,0 +1,210 @@
+package linreg
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// ElasticNet holds the settings of lasso and elastic net regression.
+// The weights minimize:
+// 1/2 Sum(dn (yn - w'zn)^2) + λ(α Sum(|wi|) + (1 - α)/2 Sum(wi^2))
+// by cyclic coordinate descent, one weight at a time, where dn are the SampleWeights (1 when nil).
+// A Tikhonov matrix is not supported.
+// α = 1 is the lasso, α = 0 is ridge regression with the same λ as LearnRegularized.
+type ElasticNet struct {
+	Alpha          float64 // mix between the L1 and the L2 penalties, in [0 : 1].
+	Tolerance      float64 // stop when no weight changes by more than Tolerance in a sweep.
+	IterationLimit int     // maximum number of sweeps over the weights for each λ.
+}
+
+// NewElasticNet is a constructor of a basic lasso:
+// Alpha = 1
+// Tolerance = 1e-8
+// IterationLimit = 10000
+func NewElasticNet() *ElasticNet {
+	e := ElasticNet{}
+	e.Alpha = 1
+	e.Tolerance = 1e-8
+	e.IterationLimit = 10000
+	return &e
+}
+
+// PathPoint holds the weights learned for a λ of a regularization path.
+type PathPoint struct {
+	Lambda     float64
+	W          []float64
+	Zeros      []int // indexes of the weights that are exactly zero.
+	Iterations int   // number of sweeps it took to converge.
+}
+
+// LearnElasticNet sets WReg with λ = Lambda, starting from zero weights.
+// w0 is not penalized when SkipBiasPenalty is set.
+func (linreg *LinearRegression) LearnElasticNet(e *ElasticNet) error {
+	if err := linreg.checkDataSet(); err != nil {
+		return err
+	}
+	w := make([]float64, len(linreg.Zn[0]))
+	if _, err := linreg.coordinateDescent(e, linreg.Lambda, w); err != nil {
+		return err
+	}
+	linreg.WReg = w
+	return nil
+}
+
+// ElasticNetPath learns the weights for every λ in lambdas, from the largest to the smallest,
+// starting each fit from the weights of the previous one.
+// Lambda and WReg are set to the last point of the path.
+// If a fit fails, the points learned so far are returned with the error.
+func (linreg *LinearRegression) ElasticNetPath(e *ElasticNet, lambdas []float64) ([]PathPoint, error) {
+	if err := linreg.checkDataSet(); err != nil {
+		return nil, err
+	}
+	sorted := append([]float64{}, lambdas...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(sorted)))
+	w := make([]float64, len(linreg.Zn[0]))
+	path := make([]PathPoint, len(sorted))
+	for i, lambda := range sorted {
+		iterations, err := linreg.coordinateDescent(e, lambda, w)
+		if err != nil {
+			return path[:i], err
+		}
+		path[i] = PathPoint{Lambda: lambda, W: append([]float64{}, w...), Zeros: ZeroWeights(w), Iterations: iterations}
+	}
+	if len(path) > 0 {
+		linreg.Lambda = path[len(path)-1].Lambda
+		linreg.WReg = append([]float64{}, path[len(path)-1].W...)
+	}
+	return path, nil
+}
+
+// ElasticNetLambdas returns count values of λ spaced logarithmically from λmax down to ratio*λmax,
+// where λmax is the smallest λ for which every penalized weight is zero.
+func (linreg *LinearRegression) ElasticNetLambdas(e *ElasticNet, count int, ratio float64) ([]float64, error) {
+	if e.Alpha <= 0 {
+		return nil, errors.New("λmax is only defined for a positive alpha")
+	}
+	if err := linreg.checkDataSet(); err != nil {
+		return nil, err
+	}
+	if count < 2 || ratio <= 0 || ratio >= 1 {
+		return nil, errors.New("lambda path needs at least 2 values and a ratio in (0 : 1)")
+	}
+	// residual of the unpenalized bias alone.
+	residual := make([]float64, len(linreg.Zn))
+	w0 := float64(0)
+	if linreg.SkipBiasPenalty {
+		zy, zz := float64(0), float64(0)
+		for i, z := range linreg.Zn {
+			zy += linreg.sampleWeight(i) * z[0] * float64(linreg.Yn[i])
+			zz += linreg.sampleWeight(i) * z[0] * z[0]
+		}
+		if zz > 0 {
+			w0 = zy / zz
+		}
+	}
+	for i, z := range linreg.Zn {
+		residual[i] = float64(linreg.Yn[i]) - z[0]*w0
+	}
+	max := float64(0)
+	for j := range linreg.Zn[0] {
+		if j == 0 && linreg.SkipBiasPenalty {
+			continue
+		}
+		rho := float64(0)
+		for i, z := range linreg.Zn {
+			rho += linreg.sampleWeight(i) * z[j] * residual[i]
+		}
+		max = math.Max(max, math.Abs(rho))
+	}
+	lambdaMax := max / e.Alpha
+	lambdas := make([]float64, count)
+	for i := range lambdas {
+		lambdas[i] = lambdaMax * math.Pow(ratio, float64(i)/float64(count-1))
+	}
+	return lambdas, nil
+}
+
+// coordinateDescent minimizes the elastic net objective with the given λ, updating w in place.
+// Each weight is set in turn to its exact minimizer with the others fixed:
+// wj = S(ρj, λα) / (Sum(dn znj^2) + λ(1 - α))
+// with ρj = Sum(dn znj(yn - Sum(zni wi, i != j))) and S(x, t) = sign(x) max(|x| - t, 0).
+// It returns the number of sweeps it took.
+func (linreg *LinearRegression) coordinateDescent(e *ElasticNet, lambda float64, w []float64) (int, error) {
+	if linreg.Tikhonov != nil {
+		return 0, errors.New("elastic net does not support a tikhonov matrix")
+	}
+	if e.Alpha < 0 || e.Alpha > 1 {
+		return 0, errors.New("alpha should be in [0 : 1]")
+	}
+	if lambda < 0 {
+		return 0, errors.New("lambda should be positive")
+	}
+	d := len(w)
+	squares := make([]float64, d)
+	for i, z := range linreg.Zn {
+		for j := 0; j < d; j++ {
+			squares[j] += linreg.sampleWeight(i) * z[j] * z[j]
+		}
+	}
+	// residual yn - w'zn kept up to date as the weights change.
+	residual := make([]float64, len(linreg.Zn))
+	for i, z := range linreg.Zn {
+		residual[i] = float64(linreg.Yn[i])
+		for j := 0; j < d; j++ {
+			residual[i] -= z[j] * w[j]
+		}
+	}
+	for iteration := 1; iteration <= e.IterationLimit; iteration++ {
+		maxChange := float64(0)
+		for j := 0; j < d; j++ {
+			if squares[j] == 0 {
+				w[j] = 0
+				continue
+			}
+			rho := float64(0)
+			for i, z := range linreg.Zn {
+				rho += linreg.sampleWeight(i) * z[j] * (residual[i] + z[j]*w[j])
+			}
+			var wj float64
+			if j == 0 && linreg.SkipBiasPenalty {
+				wj = rho / squares[j]
+			} else {
+				wj = softThreshold(rho, lambda*e.Alpha) / (squares[j] + lambda*(float64(1)-e.Alpha))
+			}
+			if change := wj - w[j]; change != 0 {
+				for i, z := range linreg.Zn {
+					residual[i] -= z[j] * change
+				}
+				maxChange = math.Max(maxChange, math.Abs(change))
+				w[j] = wj
+			}
+		}
+		if maxChange <= e.Tolerance {
+			return iteration, nil
+		}
+	}
+	return e.IterationLimit, errors.New("coordinate descent iteration limit reached")
+}
+
+// softThreshold returns sign(x) max(|x| - t, 0).
+func softThreshold(x, t float64) float64 {
+	if x > t {
+		return x - t
+	}
+	if x < -t {
+		return x + t
+	}
+	return 0
+}
+
+// ZeroWeights returns the indexes of the weights of w that are exactly zero.
+func ZeroWeights(w []float64) []int {
+	var zeros []int
+	for i, wi := range w {
+		if wi == 0 {
+			zeros = append(zeros, i)
+		}
+	}
+	return zeros
+}
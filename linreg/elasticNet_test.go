@@ -0,0 +1,81 @@
+package linreg
+
+import "testing"
+
+func TestElasticNetRidgeLimit(t *testing.T) {
+	// with α = 0 the elastic net is ridge regression with the same λ.
+	lr := fromData(points)
+	lr.Lambda = 0.1
+	e := NewElasticNet()
+	e.Alpha = 0
+	e.Tolerance = 1e-12
+	if err := lr.LearnElasticNet(e); err != nil {
+		t.Fatal(err)
+	}
+	got := lr.WReg
+	if err := lr.LearnRegularized(); err != nil {
+		t.Fatal(err)
+	}
+	checkWeights(t, "LearnElasticNet()", got, lr.WReg, 1e-9)
+
+	lr.Tikhonov = DiagonalTikhonov(1, 1, 1)
+	if err := lr.LearnElasticNet(e); err == nil {
+		t.Errorf("LearnElasticNet with a Tikhonov matrix should return an error")
+	}
+}
+
+func TestLassoLambdaMax(t *testing.T) {
+	lr := fromData(points)
+	lr.SkipBiasPenalty = true
+	e := NewElasticNet()
+	lambdas, err := lr.ElasticNetLambdas(e, 10, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// every penalized weight is exactly zero at λmax, and some are not just below it.
+	for _, c := range []struct {
+		lambda float64
+		zeros  int
+	}{{lambdas[0], 2}, {0.99 * lambdas[0], 1}} {
+		lr.Lambda = c.lambda
+		if err := lr.LearnElasticNet(e); err != nil {
+			t.Fatal(err)
+		}
+		if zeros := ZeroWeights(lr.WReg[1:]); len(zeros) != c.zeros {
+			t.Errorf("λ = %v: WReg == %v, want %d zero penalized weights", c.lambda, lr.WReg, c.zeros)
+		}
+	}
+}
+
+func TestElasticNetPath(t *testing.T) {
+	lr := fromData(points)
+	e := NewElasticNet()
+	e.Alpha = 0.5
+	e.Tolerance = 1e-12
+	lambdas, err := lr.ElasticNetLambdas(e, 5, 0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path, err := lr.ElasticNetPath(e, lambdas)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// warm starts reach the same weights as a fit from zero weights.
+	for _, p := range path {
+		lr.Lambda = p.Lambda
+		if err := lr.LearnElasticNet(e); err != nil {
+			t.Fatal(err)
+		}
+		checkWeights(t, "path weights", p.W, lr.WReg, 1e-9)
+	}
+
+	// a fit that does not converge keeps the points learned before it.
+	e.IterationLimit = 1
+	path, err = lr.ElasticNetPath(e, lambdas)
+	if err == nil {
+		t.Fatalf("ElasticNetPath with a single sweep should return an error")
+	}
+	if len(path) != 1 || path[0].Lambda != lambdas[0] {
+		t.Errorf("ElasticNetPath returned %v with the error, want the point at λmax", path)
+	}
+}
@@ -2,6 +2,7 @@ package linreg
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/santiaago/caltechx.go/linear"
+	"github.com/santiaago/caltechx.go/model"
 )
 
 //type TransformFunc func(a []float64) []float64
@@ -31,14 +33,19 @@ type LinearRegression struct {
 	TransformFunction    TransformFunc     // transform function
 	UsesTranformFunction bool              // determines if linear regression used transform function.
 	Xn                   [][]float64       // data set of random points (uniformly in interval)
+	Zn                   [][]float64       // data set Xn in the transformed space, Xn itself when no transform is used.
 	XVal                 [][]float64       // data set for validation
-	VectorSize           int               // size of vectors Xi and Wi
+	ZVal                 [][]float64       // data set XVal in the transformed space, XVal itself when no transform is used.
+	VectorSize           int               // size of vectors Xi
 	Yn                   []int             // output, evaluation of each Xi based on linear function.
+	SampleWeights        []float64         // weight of each point of Xn in learning and Ein, every point weighs 1 when nil.
 	YVal                 []int             // output, for validation
 	Wn                   []float64         // weight vector initialized at zeros.
 	WReg                 []float64         // weight vector with regularization
-	Lambda               float64           // used in weight decay
-	K                    int               // used in weight decay
+	Lambda               float64           // weight decay λ used by Fit, LearnRegularized and LearnElasticNet.
+	K                    int               // used in weight decay, λ = 10^K
+	Tikhonov             [][]float64       // matrix Γ of the weight decay penalty λw'Γ'Γw, identity when nil.
+	SkipBiasPenalty      bool              // do not penalize w0 in weight decay.
 }
 
 // NewLinearRegression is a constructor of a basic linear regression structure:
@@ -59,6 +66,7 @@ func NewLinearRegression() *LinearRegression {
 // - vector Xn with X0 at 1 and X1 and X2 random point in the defined input space.
 // - vector Yn the output of the random linear function on each point Xi. either -1 or +1  based on the linear function.
 // - vector Wn is set to zero.
+// - SampleWeights is cleared.
 func (linreg *LinearRegression) Initialize() {
 
 	// generate random target function if asked. (this is the default behavior)
@@ -73,6 +81,9 @@ func (linreg *LinearRegression) Initialize() {
 	}
 	linreg.Yn = make([]int, linreg.N)
 	linreg.Wn = make([]float64, linreg.VectorSize)
+	linreg.Zn = linreg.Xn
+	linreg.UsesTranformFunction = false
+	linreg.SampleWeights = nil
 
 	for i := 0; i < linreg.N; i++ {
 		linreg.Xn[i][0] = float64(1)
@@ -100,7 +111,7 @@ func (linreg *LinearRegression) Initialize() {
 // x1 x2 y
 // x1 x2 y
 // x1 x2 y
-// And sets Xn and Yn accordingly
+// And sets Xn and Yn accordingly, SampleWeights is cleared.
 func (linreg *LinearRegression) InitializeFromFile(filename string) error {
 	file, err := os.Open(filename)
 	if err != nil {
@@ -150,6 +161,9 @@ func (linreg *LinearRegression) InitializeFromFile(filename string) error {
 	linreg.N = numberOfLines
 	linreg.VectorSize = len(linreg.Xn[0])
 	linreg.Wn = make([]float64, linreg.VectorSize)
+	linreg.Zn = linreg.Xn
+	linreg.UsesTranformFunction = false
+	linreg.SampleWeights = nil
 
 	if err := scanner.Err(); err != nil {
 		log.Fatal(err)
@@ -161,7 +175,7 @@ func (linreg *LinearRegression) InitializeFromFile(filename string) error {
 // x1 x2 y
 // x1 x2 y
 // x1 x2 y
-// And sets Xn and Yn accordingly
+// And sets Xn and Yn accordingly, SampleWeights is cleared.
 func (linreg *LinearRegression) InitializeFromData(data [][]float64) error {
 
 	numberOfLines := 0
@@ -180,6 +194,9 @@ func (linreg *LinearRegression) InitializeFromData(data [][]float64) error {
 	linreg.N = numberOfLines
 	linreg.VectorSize = len(linreg.Xn[0])
 	linreg.Wn = make([]float64, linreg.VectorSize)
+	linreg.Zn = linreg.Xn
+	linreg.UsesTranformFunction = false
+	linreg.SampleWeights = nil
 
 	return nil
 }
@@ -199,50 +216,92 @@ func (linreg *LinearRegression) InitializeValidationFromData(data [][]float64) e
 
 	}
 	linreg.NVal = numberOfLines
+	linreg.ZVal = linreg.XVal
 	return nil
 }
 
+// ApplyTransformation sets Zn to the data set Xn transformed by TransformFunction.
+// Xn is kept unchanged and the weight vector Wn lives in the transformed space.
 func (linreg *LinearRegression) ApplyTransformation() {
 	linreg.UsesTranformFunction = true
 
-	for i := 0; i < linreg.N; i++ {
-		Xtrans := linreg.TransformFunction(linreg.Xn[i])
-		linreg.Xn[i] = Xtrans
+	linreg.Zn = make([][]float64, len(linreg.Xn))
+	for i := range linreg.Xn {
+		linreg.Zn[i] = linreg.TransformFunction(linreg.Xn[i])
 	}
-	linreg.VectorSize = len(linreg.Xn[0])
-	linreg.Wn = make([]float64, linreg.VectorSize)
+	linreg.Wn = make([]float64, len(linreg.Zn[0]))
 }
 
+// ApplyTransformationOnValidation sets ZVal to the validation set XVal transformed by TransformFunction.
 func (linreg *LinearRegression) ApplyTransformationOnValidation() {
-	for i := 0; i < linreg.NVal; i++ {
-		Xtrans := linreg.TransformFunction(linreg.XVal[i])
-		linreg.XVal[i] = Xtrans
+	linreg.ZVal = make([][]float64, len(linreg.XVal))
+	for i := range linreg.XVal {
+		linreg.ZVal[i] = linreg.TransformFunction(linreg.XVal[i])
 	}
 }
 
+// checkDataSet returns an error if the data set is empty, its outputs do not match its points
+// or its sample weights are not valid.
+// Zn is set to Xn when no transformation was applied, so that Xn and Yn can be filled directly.
+func (linreg *LinearRegression) checkDataSet() error {
+	if !linreg.UsesTranformFunction || linreg.Zn == nil {
+		linreg.Zn = linreg.Xn
+	}
+	if len(linreg.Zn) == 0 {
+		return errors.New("data set is empty")
+	}
+	if len(linreg.Yn) != len(linreg.Zn) {
+		return errors.New("data set should have one output per point")
+	}
+	return linreg.checkSampleWeights()
+}
+
+// transform returns the raw vector x = (1, x1, x2...) in the space of the weight vector:
+// TransformFunction(x) once a transformation was applied, x otherwise.
+func (linreg *LinearRegression) transform(x []float64) []float64 {
+	if linreg.UsesTranformFunction {
+		return linreg.TransformFunction(x)
+	}
+	return x
+}
+
+// g returns w'z with z the raw vector x = (1, x1, x2...) in the transformed space.
+func (linreg *LinearRegression) g(x []float64, w []float64) float64 {
+	z := linreg.transform(x)
+	gi := float64(0)
+	for j := range z {
+		gi += z[j] * w[j]
+	}
+	return gi
+}
+
 // Learn will compute the pseudo inverse X dager and set W vector accordingly
 // Xdager = (X'X)^-1 X'
+// With SampleWeights D it solves the weighted normal equations: Xdager = (X'DX)^-1 X'D
 func (linreg *LinearRegression) Learn() error {
+	if err := linreg.checkDataSet(); err != nil {
+		return err
+	}
 	// compute X' <=> X transpose
-	XTranspose := make([][]float64, len(linreg.Xn[0]))
-	for i := 0; i < len(linreg.Xn[0]); i++ {
-		XTranspose[i] = make([]float64, len(linreg.Xn))
+	XTranspose := make([][]float64, len(linreg.Zn[0]))
+	for i := 0; i < len(linreg.Zn[0]); i++ {
+		XTranspose[i] = make([]float64, len(linreg.Zn))
 	}
 
 	for i := 0; i < len(XTranspose); i++ {
 		for j := 0; j < len(XTranspose[0]); j++ {
-			XTranspose[i][j] = linreg.Xn[j][i]
+			XTranspose[i][j] = linreg.Zn[j][i] * linreg.sampleWeight(j)
 		}
 	}
 	// compute the product of X' and X
-	XProduct := make([][]float64, len(linreg.Xn[0]))
-	for i := 0; i < len(linreg.Xn[0]); i++ {
-		XProduct[i] = make([]float64, len(linreg.Xn[0]))
+	XProduct := make([][]float64, len(linreg.Zn[0]))
+	for i := 0; i < len(linreg.Zn[0]); i++ {
+		XProduct[i] = make([]float64, len(linreg.Zn[0]))
 	}
-	for k := 0; k < len(linreg.Xn[0]); k++ {
+	for k := 0; k < len(linreg.Zn[0]); k++ {
 		for i := 0; i < len(XTranspose); i++ {
 			for j := 0; j < len(XTranspose[0]); j++ {
-				XProduct[i][k] += XTranspose[i][j] * linreg.Xn[j][k]
+				XProduct[i][k] += XTranspose[i][j] * linreg.Zn[j][k]
 			}
 		}
 	}
@@ -264,6 +323,7 @@ func (linreg *LinearRegression) Learn() error {
 			}
 		}
 	}
+	linreg.Wn = make([]float64, len(XDagger))
 	linreg.setWeight(matrix(XDagger))
 	return nil
 }
@@ -280,7 +340,7 @@ func (linreg *LinearRegression) setWeight(d matrix) {
 // set Wreg
 func (linreg *LinearRegression) setWeightReg(d matrix) {
 
-	linreg.WReg = make([]float64, linreg.VectorSize)
+	linreg.WReg = make([]float64, len(d))
 
 	for i := 0; i < len(d); i++ {
 		for j := 0; j < len(d[0]); j++ {
@@ -289,56 +349,43 @@ func (linreg *LinearRegression) setWeightReg(d matrix) {
 	}
 }
 
-// Ein is the fraction of in sample points which got misclassified.
+// Ein is the fraction of in sample points which got misclassified,
+// each point counting for its sample weight.
 func (linreg *LinearRegression) Ein() float64 {
 	// XnWn
-	gInSample := make([]int, len(linreg.Xn))
-	for i := 0; i < len(linreg.Xn); i++ {
+	gInSample := make([]int, len(linreg.Zn))
+	for i := 0; i < len(linreg.Zn); i++ {
 		gi := float64(0)
-		for j := 0; j < len(linreg.Xn[0]); j++ {
-			gi += linreg.Xn[i][j] * linreg.Wn[j]
+		for j := 0; j < len(linreg.Zn[0]); j++ {
+			gi += linreg.Zn[i][j] * linreg.Wn[j]
 		}
 		gInSample[i] = linear.Sign(gi)
 	}
-	nEin := 0
-	for i := 0; i < len(gInSample); i++ {
-		if gInSample[i] != linreg.Yn[i] {
-			nEin++
-		}
-	}
-	return float64(nEin) / float64(len(gInSample))
-
+	return linreg.weightedError(gInSample)
 }
 
 // EAug is the fraction of in sample points which got misclassified plus the term
 // lambda / N * Sum(Wi^2)
 func (linreg *LinearRegression) EAugIn() float64 {
 
-	gInSample := make([]int, len(linreg.Xn))
-	for i := 0; i < len(linreg.Xn); i++ {
+	gInSample := make([]int, len(linreg.Zn))
+	for i := 0; i < len(linreg.Zn); i++ {
 		gi := float64(0)
-		for j := 0; j < len(linreg.Xn[0]); j++ {
-			gi += linreg.Xn[i][j] * linreg.WReg[j]
+		for j := 0; j < len(linreg.Zn[0]); j++ {
+			gi += linreg.Zn[i][j] * linreg.WReg[j]
 		}
 		gInSample[i] = linear.Sign(gi)
 	}
-	nEin := 0
-	for i := 0; i < len(gInSample); i++ {
-		if gInSample[i] != linreg.Yn[i] {
-			nEin++
-		}
-	}
-
-	return float64(nEin) / float64(len(gInSample))
+	return linreg.weightedError(gInSample)
 }
 
 func (linreg *LinearRegression) EValIn() float64 {
 
-	gInSample := make([]int, len(linreg.XVal))
-	for i := 0; i < len(linreg.XVal); i++ {
+	gInSample := make([]int, len(linreg.ZVal))
+	for i := 0; i < len(linreg.ZVal); i++ {
 		gi := float64(0)
-		for j := 0; j < len(linreg.XVal[0]); j++ {
-			gi += linreg.XVal[i][j] * linreg.Wn[j]
+		for j := 0; j < len(linreg.ZVal[0]); j++ {
+			gi += linreg.ZVal[i][j] * linreg.Wn[j]
 		}
 		gInSample[i] = linear.Sign(gi)
 	}
@@ -379,20 +426,15 @@ func (linreg *LinearRegression) Eout() float64 {
 			oY = evaluateTwoParams(linreg.TargetFunction, oX) * flip
 		}
 
-		gi := float64(0)
-		for j := 0; j < len(oX); j++ {
-			gi += oX[j] * linreg.Wn[j]
-		}
-
-		if linear.Sign(gi) != oY {
+		if linear.Sign(linreg.g(oX, linreg.Wn)) != oY {
 			numError++
 		}
 	}
 	return float64(numError) / float64(outOfSample)
 }
 
-// EoutFromFile only supports linear regressions with transformed data.
-// todo:(santiaago) make this more generic.
+// EoutFromFile is the fraction of points of the file misclassified by Wn.
+// The points are read as x1 x2 y and go through the transform applied to the data set, if any.
 func (linreg *LinearRegression) EoutFromFile(filename string) (float64, error) {
 
 	file, err := os.Open(filename)
@@ -429,7 +471,7 @@ func (linreg *LinearRegression) EoutFromFile(filename string) (float64, error) {
 			oX2 = x2
 		}
 
-		oX := linreg.TransformFunction([]float64{float64(1), oX1, oX2})
+		oX := []float64{float64(1), oX1, oX2}
 
 		if y, err := strconv.ParseFloat(line[2], 64); err != nil {
 			fmt.Printf("y unable to parse line %d in file %s\n", numberOfLines, filename)
@@ -438,11 +480,7 @@ func (linreg *LinearRegression) EoutFromFile(filename string) (float64, error) {
 			oY = int(y)
 		}
 
-		gi := float64(0)
-		for j := 0; j < len(oX); j++ {
-			gi += oX[j] * linreg.Wn[j]
-		}
-		if linear.Sign(gi) != oY {
+		if linear.Sign(linreg.g(oX, linreg.Wn)) != oY {
 			numError++
 		}
 		numberOfLines++
@@ -491,7 +529,7 @@ func (linreg *LinearRegression) EAugOutFromFile(filename string) (float64, error
 			oX2 = x2
 		}
 
-		oX := linreg.TransformFunction([]float64{float64(1), oX1, oX2})
+		oX := []float64{float64(1), oX1, oX2}
 
 		if y, err := strconv.ParseFloat(line[2], 64); err != nil {
 			fmt.Printf("y unable to parse line %d in file %s\n", numberOfLines, filename)
@@ -500,11 +538,7 @@ func (linreg *LinearRegression) EAugOutFromFile(filename string) (float64, error
 			oY = int(y)
 		}
 
-		gi := float64(0)
-		for j := 0; j < len(oX); j++ {
-			gi += oX[j] * linreg.WReg[j]
-		}
-		if linear.Sign(gi) != oY {
+		if linear.Sign(linreg.g(oX, linreg.WReg)) != oY {
 			numError++
 		}
 		numberOfLines++
@@ -516,37 +550,49 @@ func (linreg *LinearRegression) EAugOutFromFile(filename string) (float64, error
 // EReg
 // (Z'Z+λI)^−1 * Z'
 // WReg = (Z'Z + λI)^−1 Z'y
+// It sets Lambda = 10^K, see LearnRegularized for the regularizer actually used.
 func (linreg *LinearRegression) LearnWeightDecay() error {
 	linreg.Lambda = math.Pow(10, float64(linreg.K))
+	return linreg.LearnRegularized()
+}
 
+// LearnRegularized sets WReg with λ = Lambda and the Tikhonov matrix Γ:
+// WReg = (Z'Z + λΓ'Γ)^−1 Z'y
+// which minimizes the squared error Sum((yn - w'zn)^2) plus the penalty λw'Γ'Γw.
+// Γ is the identity when Tikhonov is nil, and w0 is not penalized when SkipBiasPenalty is set.
+// With SampleWeights D it uses Z'DZ and Z'Dy.
+// It is the entry point for any float λ, LearnWeightDecay only covers λ = 10^K.
+func (linreg *LinearRegression) LearnRegularized() error {
+	if err := linreg.checkDataSet(); err != nil {
+		return err
+	}
 	// compute X' <=> X transpose
-	XTranspose := make([][]float64, len(linreg.Xn[0]))
-	for i := 0; i < len(linreg.Xn[0]); i++ {
-		XTranspose[i] = make([]float64, len(linreg.Xn))
+	XTranspose := make([][]float64, len(linreg.Zn[0]))
+	for i := 0; i < len(linreg.Zn[0]); i++ {
+		XTranspose[i] = make([]float64, len(linreg.Zn))
 	}
 
 	for i := 0; i < len(XTranspose); i++ {
 		for j := 0; j < len(XTranspose[0]); j++ {
-			XTranspose[i][j] = linreg.Xn[j][i]
+			XTranspose[i][j] = linreg.Zn[j][i] * linreg.sampleWeight(j)
 		}
 	}
 
-	// compute lambda*Identity
-	lambdaIdentity := make([][]float64, len(linreg.Xn[0]))
-	for i := 0; i < len(lambdaIdentity); i++ {
-		lambdaIdentity[i] = make([]float64, len(lambdaIdentity))
-		lambdaIdentity[i][i] = float64(1) * linreg.Lambda
+	// compute lambda*Γ'Γ
+	lambdaIdentity, err := linreg.regularizer(linreg.Lambda)
+	if err != nil {
+		return err
 	}
 
 	// compute Z'Z
-	XProduct := make([][]float64, len(linreg.Xn[0]))
-	for i := 0; i < len(linreg.Xn[0]); i++ {
-		XProduct[i] = make([]float64, len(linreg.Xn[0]))
+	XProduct := make([][]float64, len(linreg.Zn[0]))
+	for i := 0; i < len(linreg.Zn[0]); i++ {
+		XProduct[i] = make([]float64, len(linreg.Zn[0]))
 	}
-	for k := 0; k < len(linreg.Xn[0]); k++ {
+	for k := 0; k < len(linreg.Zn[0]); k++ {
 		for i := 0; i < len(XTranspose); i++ {
 			for j := 0; j < len(XTranspose[0]); j++ {
-				XProduct[i][k] += XTranspose[i][j] * linreg.Xn[j][k]
+				XProduct[i][k] += XTranspose[i][j] * linreg.Zn[j][k]
 			}
 		}
 	}
@@ -592,8 +638,8 @@ func (linreg *LinearRegression) CompareInSample(f linear.LinearFunc, nParams int
 
 	for i := 0; i < len(linreg.Xn); i++ {
 		gi := float64(0)
-		for j := 0; j < len(linreg.Xn[0]); j++ {
-			gi += linreg.Xn[i][j] * linreg.Wn[j]
+		for j := 0; j < len(linreg.Zn[0]); j++ {
+			gi += linreg.Zn[i][j] * linreg.Wn[j]
 		}
 		gInSample[i] = float64(linear.Sign(gi))
 		if nParams == 2 {
@@ -627,10 +673,7 @@ func (linreg *LinearRegression) CompareOutOfSample(f linear.LinearFunc, nParams
 			oX[j] = linreg.Interval.RandFloat()
 		}
 
-		gi := float64(0)
-		for j := 0; j < len(oX); j++ {
-			gi += oX[j] * linreg.Wn[j]
-		}
+		gi := linreg.g(oX, linreg.Wn)
 		if nParams == 2 {
 			if linear.Sign(gi) != int(f(oX[1], oX[2])) {
 				diff++
@@ -645,18 +688,61 @@ func (linreg *LinearRegression) CompareOutOfSample(f linear.LinearFunc, nParams
 	return float64(diff) / float64(outOfSample)
 }
 
+// Fit sets Xn and Yn from the dataset d, with x0 = 1 added to every input,
+// applies TransformFunction if it is set and learns Wn.
+// When Lambda is positive the weights are learned by LearnRegularized and Wn is set to WReg.
+// SampleWeights is cleared with the previous data set.
+func (linreg *LinearRegression) Fit(d model.Dataset) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	data := make([][]float64, d.Len())
+	for i := range d.X {
+		data[i] = append(append([]float64{}, d.X[i]...), float64(d.Y[i]))
+	}
+	if err := linreg.InitializeFromData(data); err != nil {
+		return err
+	}
+	if linreg.TransformFunction != nil {
+		linreg.ApplyTransformation()
+	}
+	if linreg.Lambda > 0 {
+		if err := linreg.LearnRegularized(); err != nil {
+			return err
+		}
+		copy(linreg.Wn, linreg.WReg)
+		return nil
+	}
+	return linreg.Learn()
+}
+
+// Predict returns sign(w'z) where z is the raw input x with x0 = 1 added,
+// transformed by TransformFunction if the data set was transformed.
+func (linreg *LinearRegression) Predict(x []float64) float64 {
+	return float64(linear.Sign(linreg.g(append([]float64{float64(1)}, x...), linreg.Wn)))
+}
+
+// Score returns the fraction of points of d misclassified by Wn.
+func (linreg *LinearRegression) Score(d model.Dataset) float64 {
+	return model.ClassificationError(linreg, d)
+}
+
+// Weights returns a copy of the weight vector Wn.
+func (linreg *LinearRegression) Weights() []float64 {
+	return append([]float64{}, linreg.Wn...)
+}
+
 type TransformFunc func([]float64) []float64
 
+// TransformDataSet sets TransformFunction to f and Zn to the data set Xn transformed by f,
+// with vectors of size newSize.
 func (linreg *LinearRegression) TransformDataSet(f TransformFunc, newSize int) {
-	for i := 0; i < len(linreg.Xn); i++ {
-		oldXn := linreg.Xn[i]
-		newXn := f(oldXn)
-		linreg.Xn[i] = make([]float64, newSize)
-		for j := 0; j < len(newXn); j++ {
-			linreg.Xn[i][j] = newXn[j]
-		}
+	linreg.TransformFunction = func(x []float64) []float64 {
+		z := make([]float64, newSize)
+		copy(z, f(x))
+		return z
 	}
-	linreg.Wn = make([]float64, newSize)
+	linreg.ApplyTransformation()
 }
 
 // evaluate will map function f in point p with respect to the current y point.
@@ -0,0 +1,34 @@
+package linreg
+
+import (
+	"math"
+	"testing"
+)
+
+// points is a small data set of rows x1 x2 y shared by the tests.
+var points = [][]float64{
+	{0.1, 0.5, 1}, {-0.3, 0.2, 1}, {0.7, -0.4, -1}, {-0.6, -0.8, -1},
+	{0.9, 0.1, -1}, {-0.2, 0.9, 1}, {0.4, 0.3, 1}, {-0.5, -0.1, -1},
+	{0.2, -0.7, -1}, {-0.9, 0.6, 1},
+}
+
+// fromData returns a linear regression initialized with data.
+func fromData(data [][]float64) *LinearRegression {
+	lr := NewLinearRegression()
+	lr.InitializeFromData(data)
+	return lr
+}
+
+// checkWeights reports an error if got and want differ by more than tolerance.
+func checkWeights(t *testing.T, name string, got, want []float64, tolerance float64) {
+	if len(got) != len(want) {
+		t.Errorf("%s == %v, want %v", name, got, want)
+		return
+	}
+	for i := range got {
+		if math.Abs(got[i]-want[i]) > tolerance {
+			t.Errorf("%s == %v, want %v", name, got, want)
+			return
+		}
+	}
+}
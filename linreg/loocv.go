@@ -0,0 +1,93 @@
+package linreg
+
+import (
+	"errors"
+	"math"
+
+	"github.com/santiaago/caltechx.go/linear"
+)
+
+// LOOCV returns the exact leave one out cross validation errors of the linear regression learned by Learn:
+// the mean squared error and the fraction of misclassified points.
+// See looCV for how they are computed without refitting N times.
+func (linreg *LinearRegression) LOOCV() (float64, float64, error) {
+	return linreg.looCV(float64(0))
+}
+
+// LOOCVWeightDecay returns the exact leave one out cross validation errors of the linear regression
+// learned by LearnWeightDecay, with λ = 10^K:
+// the mean squared error and the fraction of misclassified points.
+func (linreg *LinearRegression) LOOCVWeightDecay() (float64, float64, error) {
+	return linreg.looCV(math.Pow(10, float64(linreg.K)))
+}
+
+// LOOCVRegularized returns the exact leave one out cross validation errors of the linear regression
+// learned by LearnRegularized, with the current Lambda:
+// the mean squared error and the fraction of misclassified points.
+func (linreg *LinearRegression) LOOCVRegularized() (float64, float64, error) {
+	return linreg.looCV(linreg.Lambda)
+}
+
+// looCV uses the hat matrix H = Z(Z'DZ + λΓ'Γ)^-1 Z'D of the data set Zn, Yn with sample weights D.
+// Leaving point n out changes its residual to:
+// yn - g-n(xn) = (yn - g(xn)) / (1 - Hnn)
+// so the errors follow from a single fit.
+// The errors are averaged with the sample weights.
+func (linreg *LinearRegression) looCV(lambda float64) (float64, float64, error) {
+	if err := linreg.checkDataSet(); err != nil {
+		return 0, 0, err
+	}
+	d := len(linreg.Zn[0])
+	// compute Z'Z + λΓ'Γ and Z'y
+	a, err := linreg.regularizer(lambda)
+	if err != nil {
+		return 0, 0, err
+	}
+	xy := make([]float64, d)
+	for n, x := range linreg.Zn {
+		dn := linreg.sampleWeight(n)
+		for i := 0; i < d; i++ {
+			for j := 0; j < d; j++ {
+				a[i][j] += dn * x[i] * x[j]
+			}
+			xy[i] += dn * x[i] * float64(linreg.Yn[n])
+		}
+	}
+	aInv, err := a.inverse()
+	if err != nil {
+		return 0, 0, err
+	}
+	w := make([]float64, d)
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			w[i] += aInv[i][j] * xy[j]
+		}
+	}
+
+	squared, misclassified, total := float64(0), float64(0), float64(0)
+	for n, x := range linreg.Zn {
+		dn := linreg.sampleWeight(n)
+		g := float64(0)
+		h := float64(0)
+		for i := 0; i < d; i++ {
+			g += x[i] * w[i]
+			for j := 0; j < d; j++ {
+				h += dn * x[i] * aInv[i][j] * x[j]
+			}
+		}
+		if h >= float64(1) {
+			return 0, 0, errors.New("leave one out error is undefined for points with leverage 1")
+		}
+		y := float64(linreg.Yn[n])
+		e := (y - g) / (float64(1) - h)
+		total += dn
+		squared += dn * e * e
+		if linear.Sign(y-e) != linreg.Yn[n] {
+			misclassified += dn
+		}
+	}
+	if total == 0 {
+		return 0, 0, errors.New("sample weights should not all be zero")
+	}
+	return squared / total, misclassified / total, nil
+}
@@ -0,0 +1,89 @@
+package linreg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/santiaago/caltechx.go/linear"
+)
+
+// bruteForceLOOCV refits the linear regression on the data set without each point.
+func bruteForceLOOCV(t *testing.T, data [][]float64, k int, weightDecay bool) (float64, float64) {
+	squared := float64(0)
+	misclassified := 0
+	for n := range data {
+		var train [][]float64
+		train = append(train, data[:n]...)
+		train = append(train, data[n+1:]...)
+		lr := fromData(train)
+		lr.K = k
+		var w []float64
+		if weightDecay {
+			if err := lr.LearnWeightDecay(); err != nil {
+				t.Fatal(err)
+			}
+			w = lr.WReg
+		} else {
+			if err := lr.Learn(); err != nil {
+				t.Fatal(err)
+			}
+			w = lr.Wn
+		}
+		g := w[0] + w[1]*data[n][0] + w[2]*data[n][1]
+		y := data[n][2]
+		squared += (y - g) * (y - g)
+		if float64(linear.Sign(g)) != y {
+			misclassified++
+		}
+	}
+	return squared / float64(len(data)), float64(misclassified) / float64(len(data))
+}
+
+func TestLOOCV(t *testing.T) {
+	lr := fromData(points)
+	lr.K = -1
+
+	for _, weightDecay := range []bool{false, true} {
+		squared, classification, err := lr.LOOCV()
+		if weightDecay {
+			squared, classification, err = lr.LOOCVWeightDecay()
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantSquared, wantClassification := bruteForceLOOCV(t, points, lr.K, weightDecay)
+		if math.Abs(squared-wantSquared) > 1e-9 || classification != wantClassification {
+			t.Errorf("weight decay %v: LOOCV() == %v, %v, want %v, %v", weightDecay, squared, classification, wantSquared, wantClassification)
+		}
+	}
+}
+
+func TestDataSetFilledDirectly(t *testing.T) {
+	lr := NewLinearRegression()
+	if err := lr.Learn(); err == nil {
+		t.Errorf("Learn on an empty data set should return an error")
+	}
+	if _, _, err := lr.LOOCV(); err == nil {
+		t.Errorf("LOOCV on an empty data set should return an error")
+	}
+
+	lr.Xn = [][]float64{{1, 0}, {1, 1}, {1, 2}, {1, 3}}
+	lr.Yn = []int{-1, -1, 1, 1}
+	if err := lr.Learn(); err != nil {
+		t.Fatalf("Learn returned error %v", err)
+	}
+	if math.Abs(lr.Wn[0]+1.2) > 1e-9 || math.Abs(lr.Wn[1]-0.8) > 1e-9 {
+		t.Errorf("Wn == %v, want [-1.2 0.8]", lr.Wn)
+	}
+	if err := lr.LearnWeightDecay(); err != nil {
+		t.Errorf("LearnWeightDecay returned error %v", err)
+	}
+	if _, _, err := lr.LOOCV(); err != nil {
+		t.Errorf("LOOCV returned error %v", err)
+	}
+
+	lr.Yn = lr.Yn[:3]
+	if err := lr.Learn(); err == nil {
+		t.Errorf("Learn with fewer outputs than points should return an error")
+	}
+}
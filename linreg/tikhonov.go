@@ -0,0 +1,65 @@
+package linreg
+
+import (
+	"errors"
+	"math"
+)
+
+// regularizer returns the matrix λΓ'Γ of the weight decay penalty in the space of Zn,
+// with the first row and column set to zero when SkipBiasPenalty is set.
+func (linreg *LinearRegression) regularizer(lambda float64) (matrix, error) {
+	if len(linreg.Zn) == 0 {
+		return nil, errors.New("data set is empty")
+	}
+	d := len(linreg.Zn[0])
+	gamma := linreg.Tikhonov
+	if gamma == nil {
+		gamma = make([][]float64, d)
+		for i := range gamma {
+			gamma[i] = make([]float64, d)
+			gamma[i][i] = float64(1)
+		}
+	}
+	for _, row := range gamma {
+		if len(row) != d {
+			return nil, errors.New("tikhonov matrix should have as many columns as the weight vector")
+		}
+	}
+	r := make(matrix, d)
+	for i := 0; i < d; i++ {
+		r[i] = make([]float64, d)
+		for j := 0; j < d; j++ {
+			if linreg.SkipBiasPenalty && (i == 0 || j == 0) {
+				continue
+			}
+			for k := range gamma {
+				r[i][j] += gamma[k][i] * gamma[k][j]
+			}
+			r[i][j] *= lambda
+		}
+	}
+	return r, nil
+}
+
+// DiagonalTikhonov returns the matrix Γ = diag(gammas),
+// whose penalty is Sum(γq^2 wq^2).
+func DiagonalTikhonov(gammas ...float64) [][]float64 {
+	gamma := make([][]float64, len(gammas))
+	for i, g := range gammas {
+		gamma[i] = make([]float64, len(gammas))
+		gamma[i][i] = g
+	}
+	return gamma
+}
+
+// SoftOrderTikhonov returns the diagonal matrix Γ whose penalty is the soft order constraint:
+// Sum(base^q wq^2)
+// where q = degrees[i] is the order of feature i, e.g. the degree of a Legendre polynomial.
+// base > 1 penalizes high orders the most and favors smooth fits, base < 1 favors high order fits.
+func SoftOrderTikhonov(degrees []int, base float64) [][]float64 {
+	gammas := make([]float64, len(degrees))
+	for i, q := range degrees {
+		gammas[i] = math.Sqrt(math.Pow(base, float64(q)))
+	}
+	return DiagonalTikhonov(gammas...)
+}
@@ -0,0 +1,81 @@
+package linreg
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkipBiasPenalty(t *testing.T) {
+	// not penalizing w0 is the same as Γ = diag(0, 1, 1).
+	skip := fromData(points)
+	skip.Lambda = 0.7
+	skip.SkipBiasPenalty = true
+	diagonal := fromData(points)
+	diagonal.Lambda = 0.7
+	diagonal.Tikhonov = DiagonalTikhonov(0, 1, 1)
+	for _, lr := range []*LinearRegression{skip, diagonal} {
+		if err := lr.LearnRegularized(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkWeights(t, "WReg with SkipBiasPenalty", skip.WReg, diagonal.WReg, 1e-12)
+}
+
+func TestTikhonovClosedForm(t *testing.T) {
+	gamma := [][]float64{{1, 2, 0}, {0, 1, -1}, {0.5, 0, 3}}
+	lambda := 0.3
+	lr := fromData(points)
+	lr.Lambda = lambda
+	lr.Tikhonov = gamma
+	if err := lr.LearnRegularized(); err != nil {
+		t.Fatal(err)
+	}
+
+	// WReg = (Z'Z + λΓ'Γ)^-1 Z'y
+	d := len(gamma)
+	a := make(matrix, d)
+	b := make([]float64, d)
+	for i := 0; i < d; i++ {
+		a[i] = make([]float64, d)
+		for j := 0; j < d; j++ {
+			for _, z := range lr.Zn {
+				a[i][j] += z[i] * z[j]
+			}
+			for _, g := range gamma {
+				a[i][j] += lambda * g[i] * g[j]
+			}
+		}
+		for n, z := range lr.Zn {
+			b[i] += z[i] * float64(lr.Yn[n])
+		}
+	}
+	inverse, err := a.inverse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := make([]float64, d)
+	for i := 0; i < d; i++ {
+		for j := 0; j < d; j++ {
+			want[i] += inverse[i][j] * b[j]
+		}
+	}
+	checkWeights(t, "WReg", lr.WReg, want, 1e-9)
+}
+
+func TestSoftOrderTikhonov(t *testing.T) {
+	if gamma, want := SoftOrderTikhonov([]int{0, 1, 2, 2}, 4), DiagonalTikhonov(1, 2, 4, 4); !reflect.DeepEqual(gamma, want) {
+		t.Errorf("SoftOrderTikhonov(0 1 2 2, 4) == %v, want %v", gamma, want)
+	}
+	// a base of 1 penalizes every order the same, as plain weight decay.
+	soft := fromData(points)
+	soft.Lambda = 0.5
+	soft.Tikhonov = SoftOrderTikhonov([]int{0, 1, 1}, 1)
+	plain := fromData(points)
+	plain.Lambda = 0.5
+	for _, lr := range []*LinearRegression{soft, plain} {
+		if err := lr.LearnRegularized(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	checkWeights(t, "WReg with base 1", soft.WReg, plain.WReg, 1e-12)
+}
@@ -0,0 +1,51 @@
+package linreg
+
+import "errors"
+
+// sampleWeight returns the weight of point i of the data set, 1 when SampleWeights is nil.
+func (linreg *LinearRegression) sampleWeight(i int) float64 {
+	if linreg.SampleWeights == nil {
+		return float64(1)
+	}
+	return linreg.SampleWeights[i]
+}
+
+// checkSampleWeights returns an error if SampleWeights is set and does not hold
+// a non negative weight for every point of the data set.
+func (linreg *LinearRegression) checkSampleWeights() error {
+	if linreg.SampleWeights == nil {
+		return nil
+	}
+	if len(linreg.SampleWeights) != len(linreg.Zn) {
+		return errors.New("sample weights should have one weight per point of the data set")
+	}
+	for _, w := range linreg.SampleWeights {
+		if w < 0 {
+			return errors.New("sample weights should not be negative")
+		}
+	}
+	return nil
+}
+
+// weightedError returns the fraction of points whose prediction g[i] differs from Yn[i],
+// each point counting for its sample weight:
+// Sum(dn [gn != yn]) / Sum(dn)
+// Every point weighs 1 when the sample weights are not valid for the data set.
+func (linreg *LinearRegression) weightedError(g []int) float64 {
+	valid := linreg.checkSampleWeights() == nil
+	errorWeight, total := float64(0), float64(0)
+	for i := range g {
+		d := float64(1)
+		if valid {
+			d = linreg.sampleWeight(i)
+		}
+		total += d
+		if g[i] != linreg.Yn[i] {
+			errorWeight += d
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return errorWeight / total
+}
@@ -0,0 +1,109 @@
+package linreg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/santiaago/caltechx.go/linear"
+	"github.com/santiaago/caltechx.go/model"
+)
+
+func TestSampleWeights(t *testing.T) {
+	e := NewElasticNet()
+	e.Alpha = 0.5
+	e.Tolerance = 1e-12
+	solvers := []struct {
+		name    string
+		learn   func(lr *LinearRegression) error
+		weights func(lr *LinearRegression) []float64
+		ein     func(lr *LinearRegression) float64
+	}{
+		{"Learn", (*LinearRegression).Learn, func(lr *LinearRegression) []float64 { return lr.Wn }, (*LinearRegression).Ein},
+		{"LearnRegularized", (*LinearRegression).LearnRegularized, func(lr *LinearRegression) []float64 { return lr.WReg }, (*LinearRegression).EAugIn},
+		{"LearnElasticNet", func(lr *LinearRegression) error { return lr.LearnElasticNet(e) }, func(lr *LinearRegression) []float64 { return lr.WReg }, (*LinearRegression).EAugIn},
+	}
+	for _, s := range solvers {
+		// a weight of 2 is the same as having the point twice in the data set.
+		weighted := fromData(points)
+		weighted.SampleWeights = []float64{2, 1, 1, 1, 2, 1, 1, 1, 1, 1}
+		duplicated := fromData(append(append([][]float64{}, points...), points[0], points[4]))
+		for _, lr := range []*LinearRegression{weighted, duplicated} {
+			lr.Lambda = 0.3
+			if err := s.learn(lr); err != nil {
+				t.Fatalf("%s returned error %v", s.name, err)
+			}
+		}
+		checkWeights(t, s.name+" with sample weights", s.weights(weighted), s.weights(duplicated), 1e-9)
+		if ein, want := s.ein(weighted), s.ein(duplicated); math.Abs(ein-want) > 1e-12 {
+			t.Errorf("%s: in sample error %v with sample weights, want %v", s.name, ein, want)
+		}
+	}
+}
+
+func TestSampleWeightsLOOCV(t *testing.T) {
+	weights := []float64{2, 1, 0.5, 1, 3, 1, 1, 0.5, 1, 2}
+	lr := fromData(points)
+	lr.SampleWeights = weights
+	lr.Lambda = 0.3
+	squared, classification, err := lr.LOOCVRegularized()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// refit without each point, keeping the weights of the others,
+	// and average the errors with the sample weights.
+	wantSquared, wantClassification, total := float64(0), float64(0), float64(0)
+	for n := range points {
+		var train [][]float64
+		var trainWeights []float64
+		train = append(append(train, points[:n]...), points[n+1:]...)
+		trainWeights = append(append(trainWeights, weights[:n]...), weights[n+1:]...)
+		out := fromData(train)
+		out.SampleWeights = trainWeights
+		out.Lambda = lr.Lambda
+		if err := out.LearnRegularized(); err != nil {
+			t.Fatal(err)
+		}
+		w := out.WReg
+		g := w[0] + w[1]*points[n][0] + w[2]*points[n][1]
+		y := points[n][2]
+		total += weights[n]
+		wantSquared += weights[n] * (y - g) * (y - g)
+		if float64(linear.Sign(g)) != y {
+			wantClassification += weights[n]
+		}
+	}
+	wantSquared /= total
+	wantClassification /= total
+	if math.Abs(squared-wantSquared) > 1e-9 || math.Abs(classification-wantClassification) > 1e-12 {
+		t.Errorf("LOOCVRegularized() == %v, %v, want %v, %v", squared, classification, wantSquared, wantClassification)
+	}
+}
+
+func TestStaleSampleWeights(t *testing.T) {
+	lr := fromData(points)
+	lr.SampleWeights = []float64{1, 2}
+	if err := lr.Learn(); err == nil {
+		t.Errorf("Learn with fewer sample weights than points should return an error")
+	}
+	// errors fall back to unit weights rather than index past the sample weights.
+	lr.SampleWeights = nil
+	want := lr.Ein()
+	lr.SampleWeights = []float64{1, 2}
+	if ein := lr.Ein(); ein != want {
+		t.Errorf("Ein() == %v with too few sample weights, want %v", ein, want)
+	}
+
+	lr.InitializeFromData(points[:4])
+	if lr.SampleWeights != nil {
+		t.Errorf("SampleWeights == %v after InitializeFromData, want nil", lr.SampleWeights)
+	}
+	lr.SampleWeights = []float64{1, 2}
+	d := model.Dataset{X: [][]float64{{0.1, 0.5}, {-0.3, 0.2}, {0.7, -0.4}, {-0.6, -0.8}}, Y: []int{1, 1, -1, -1}}
+	if err := lr.Fit(d); err != nil {
+		t.Fatalf("Fit returned error %v", err)
+	}
+	if lr.SampleWeights != nil {
+		t.Errorf("SampleWeights == %v after Fit, want nil", lr.SampleWeights)
+	}
+}
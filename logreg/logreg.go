@@ -2,7 +2,9 @@ package logreg
 
 import (
 	"fmt"
+	GD "github.com/santiaago/caltechx.go/gradientDescent"
 	"github.com/santiaago/caltechx.go/linear"
+	"github.com/santiaago/caltechx.go/model"
 	"math"
 	"math/rand"
 )
@@ -91,6 +93,44 @@ func (logreg *LogisticRegression) Learn() {
 	}
 }
 
+// CrossEntropyObjective returns the in sample cross entropy error as a function of the weights:
+// Ein(w) = 1/N Sum(log(1 + exp(-yn*w'xn)))
+// with its gradient:
+// grad Ein(w) = -1/N Sum(yn*xn / (1 + exp(yn*w'xn)))
+// so that it can be minimized by any optimizer of the gradientDescent package.
+func (logreg *LogisticRegression) CrossEntropyObjective() GD.Objective {
+	f := func(w []float64) float64 {
+		e := float64(0)
+		for i := range logreg.Xn {
+			e += softplus(float64(-logreg.Yn[i]) * dot(logreg.Xn[i], w))
+		}
+		return e / float64(len(logreg.Xn))
+	}
+	grad := func(w []float64) []float64 {
+		g := make([]float64, len(w))
+		for i := range logreg.Xn {
+			yi := float64(logreg.Yn[i])
+			theta := logistic(-yi * dot(logreg.Xn[i], w))
+			for j := range g {
+				g[j] -= yi * logreg.Xn[i][j] * theta
+			}
+		}
+		for j := range g {
+			g[j] /= float64(len(logreg.Xn))
+		}
+		return g
+	}
+	return GD.Objective{F: f, Grad: grad}
+}
+
+// LearnWithMinimizer sets the weight vector Wn by minimizing the in sample cross entropy error
+// with the given optimizer (BFGS, L-BFGS, conjugate gradient...) starting at the current Wn.
+func (logreg *LogisticRegression) LearnWithMinimizer(m GD.Minimizer) error {
+	w, err := m.Minimize(logreg.CrossEntropyObjective(), logreg.Wn)
+	logreg.Wn = w
+	return err
+}
+
 // Returns the gradient vector with respect to:
 // the current sample wi
 // the current target value:yi
@@ -108,12 +148,13 @@ func (logreg *LogisticRegression) Gradient(wi []float64, yi int) []float64 {
 	}
 	b := make([]float64, len(logreg.Wn))
 	copy(b, logreg.Wn)
-	d := float64(1) + math.Exp(float64(yi)*dot(a, b))
+	// 1 / (1 + exp(yi*w'x)) computed without overflow.
+	theta := logistic(float64(-yi) * dot(a, b))
 
 	//vG = [-1.0 * x / d for x in vector]
 	vg := make([]float64, len(v))
 	for i, _ := range v {
-		vg[i] = float64(-1) * v[i] / d
+		vg[i] = float64(-1) * v[i] * theta
 	}
 	return vg
 }
@@ -141,6 +182,21 @@ func (logreg *LogisticRegression) Converged(wOld []float64) bool {
 	return norm(diff) < logreg.Epsilon
 }
 
+// softplus returns log(1 + exp(s)) without overflow for large |s|:
+// max(s, 0) + log(1 + exp(-|s|))
+func softplus(s float64) float64 {
+	return math.Max(s, 0) + math.Log1p(math.Exp(-math.Abs(s)))
+}
+
+// logistic returns θ(s) = 1 / (1 + exp(-s)) without overflow for large |s|.
+func logistic(s float64) float64 {
+	if s >= 0 {
+		return float64(1) / (float64(1) + math.Exp(-s))
+	}
+	e := math.Exp(s)
+	return e / (float64(1) + e)
+}
+
 func norm(v []float64) float64 {
 	return math.Sqrt(dot(v, v))
 }
@@ -183,9 +239,15 @@ func evaluate(f linear.LinearFunc, p []float64) int {
 	return 1
 }
 
-// func (lr *LogisticRegression) Ein() float64 {
-
-// }
+// Ein is the in sample error of the logistic regression.
+// It uses the cross entropy error on the data set Xn, Yn and the weight vector Wn
+func (logreg *LogisticRegression) Ein() float64 {
+	cee := float64(0)
+	for i := range logreg.Xn {
+		cee += logreg.CrossEntropyError(logreg.Xn[i], logreg.Yn[i])
+	}
+	return cee / float64(len(logreg.Xn))
+}
 
 // Eout is the out of sample error of the logistic regression.
 // It uses the cross entropy error given a generated data set and the weight vector Wn
@@ -210,5 +272,47 @@ func (logreg *LogisticRegression) Eout() float64 {
 // with respect to weight vector Wn based on formula:
 // log(1 + exp(-y*sample*w))
 func (logreg *LogisticRegression) CrossEntropyError(sample []float64, Y int) float64 {
-	return math.Log(float64(1) + math.Exp(float64(-Y)*dot(sample, logreg.Wn)))
+	return softplus(float64(-Y) * dot(sample, logreg.Wn))
+}
+
+// Fit sets Xn and Yn from the dataset d, with x0 = 1 added to every input,
+// resets Wn to zero and learns with SGD.
+func (logreg *LogisticRegression) Fit(d model.Dataset) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	logreg.N = d.Len()
+	logreg.VectorSize = len(d.X[0]) + 1
+	logreg.Xn = make([][]float64, logreg.N)
+	logreg.Yn = make([]int, logreg.N)
+	for i := range d.X {
+		logreg.Xn[i] = append([]float64{float64(1)}, d.X[i]...)
+		logreg.Yn[i] = d.Y[i]
+	}
+	logreg.Wn = make([]float64, logreg.VectorSize)
+	logreg.Learn()
+	return nil
+}
+
+// Predict returns sign(w'x) with x0 = 1 added to the input x.
+func (logreg *LogisticRegression) Predict(x []float64) float64 {
+	return float64(linear.Sign(dot(append([]float64{float64(1)}, x...), logreg.Wn)))
+}
+
+// Probability returns the probability of x being labeled +1:
+// θ(w'x) = 1 / (1 + exp(-w'x))
+// with x0 = 1 added to the input x.
+func (logreg *LogisticRegression) Probability(x []float64) float64 {
+	return logistic(dot(append([]float64{float64(1)}, x...), logreg.Wn))
+}
+
+// Score returns the fraction of points of d misclassified by Wn.
+// The cross entropy error is given by Ein and Eout.
+func (logreg *LogisticRegression) Score(d model.Dataset) float64 {
+	return model.ClassificationError(logreg, d)
+}
+
+// Weights returns a copy of the weight vector Wn.
+func (logreg *LogisticRegression) Weights() []float64 {
+	return append([]float64{}, logreg.Wn...)
 }
@@ -0,0 +1,76 @@
+package logreg
+
+import (
+	"math"
+	"testing"
+
+	GD "github.com/santiaago/caltechx.go/gradientDescent"
+	"github.com/santiaago/caltechx.go/model"
+)
+
+func TestCrossEntropyLargeSignal(t *testing.T) {
+	logreg := NewLogisticRegression()
+	logreg.Xn = [][]float64{{1, 1, 1}, {1, -1, -1}}
+	logreg.Yn = []int{-1, 1}
+	w := []float64{0, 500, 500}
+	logreg.Wn = w
+
+	// -y*w'x = 1000, far past the overflow of exp.
+	if e := logreg.CrossEntropyError(logreg.Xn[0], logreg.Yn[0]); math.Abs(e-1000) > 1e-9 {
+		t.Errorf("CrossEntropyError() == %v, want 1000", e)
+	}
+	o := logreg.CrossEntropyObjective()
+	if f := o.F(w); math.Abs(f-1000) > 1e-9 {
+		t.Errorf("objective == %v, want 1000", f)
+	}
+	for _, g := range o.Grad(w) {
+		if math.IsNaN(g) || math.IsInf(g, 0) {
+			t.Errorf("gradient == %v, want finite values", o.Grad(w))
+			break
+		}
+	}
+	if gt := logreg.Gradient(logreg.Xn[0][1:], logreg.Yn[0]); math.Abs(gt[1]-1) > 1e-9 {
+		t.Errorf("Gradient() == %v, want [1 1 1]", gt)
+	}
+}
+
+func TestPredictAndScore(t *testing.T) {
+	logreg := NewLogisticRegression()
+	logreg.Wn = []float64{0, 1, 0}
+	if p := logreg.Predict([]float64{0.5, 0}); p != 1 {
+		t.Errorf("Predict() == %v, want 1", p)
+	}
+	if p := logreg.Predict([]float64{-0.5, 0}); p != -1 {
+		t.Errorf("Predict() == %v, want -1", p)
+	}
+	if p, want := logreg.Probability([]float64{0.5, 0}), 1/(1+math.Exp(-0.5)); math.Abs(p-want) > 1e-12 {
+		t.Errorf("Probability() == %v, want %v", p, want)
+	}
+	d := model.Dataset{X: [][]float64{{0.5, 0}, {-0.5, 0}}, Y: []int{1, 1}}
+	if s := logreg.Score(d); s != 0.5 {
+		t.Errorf("Score() == %v, want 0.5", s)
+	}
+}
+
+func TestLearnWithMinimizer(t *testing.T) {
+	// labels given by the line x1 + x2 = 0.2, so the data set is separable.
+	x := [][]float64{{0.9, 0.1}, {0.4, 0.5}, {-0.2, 0.8}, {0.7, -0.3}, {-0.6, -0.4}, {0.1, -0.9}, {-0.8, 0.3}, {0.2, -0.5}}
+	sgd := NewLogisticRegression()
+	sgd.N = len(x)
+	for _, xi := range x {
+		sgd.Xn = append(sgd.Xn, append([]float64{1}, xi...))
+	}
+	sgd.Yn = []int{1, 1, 1, 1, -1, -1, -1, -1}
+	sgd.Wn = make([]float64, 3)
+	bfgs := *sgd
+	sgd.Learn()
+
+	if err := bfgs.LearnWithMinimizer(GD.NewBFGS()); err != nil {
+		t.Fatalf("LearnWithMinimizer returned error %v", err)
+	}
+	// the cross entropy of a separable data set goes to 0 as the weights grow.
+	ein := func(lr *LogisticRegression) float64 { return lr.CrossEntropyObjective().F(lr.Wn) }
+	if ein(&bfgs) >= ein(sgd) || ein(&bfgs) > 1e-3 {
+		t.Errorf("Ein == %v with BFGS, want under 1e-3 and under %v with SGD", ein(&bfgs), ein(sgd))
+	}
+}
@@ -0,0 +1,122 @@
+package model
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/santiaago/caltechx.go/linear"
+)
+
+// Dataset holds the examples a learner is fitted on or scored against.
+// X holds the raw inputs without the x0 = 1 coordinate, which each learner adds itself.
+// Y holds the labels, either -1 or +1.
+type Dataset struct {
+	X [][]float64
+	Y []int
+}
+
+// FromData builds a dataset from rows with the following format:
+// x1 x2 ... y
+// which is the format of the data files of the course.
+func FromData(data [][]float64) Dataset {
+	d := Dataset{X: make([][]float64, len(data)), Y: make([]int, len(data))}
+	for i, row := range data {
+		d.X[i] = append([]float64{}, row[:len(row)-1]...)
+		d.Y[i] = int(row[len(row)-1])
+	}
+	return d
+}
+
+// Len returns the number of examples of the dataset.
+func (d Dataset) Len() int {
+	return len(d.Y)
+}
+
+// Subset returns the dataset made of the examples at the given indexes.
+func (d Dataset) Subset(indexes []int) Dataset {
+	s := Dataset{X: make([][]float64, len(indexes)), Y: make([]int, len(indexes))}
+	for i, index := range indexes {
+		s.X[i] = d.X[index]
+		s.Y[i] = d.Y[index]
+	}
+	return s
+}
+
+// Validate returns an error if the dataset is empty or X and Y have different lengths.
+func (d Dataset) Validate() error {
+	if len(d.X) != len(d.Y) {
+		return errors.New("dataset should have as many inputs as labels")
+	}
+	if len(d.X) == 0 {
+		return errors.New("dataset is empty")
+	}
+	return nil
+}
+
+// Model is a learned hypothesis g.
+// Predict returns the label predicted by g on the raw input x, either -1 or +1.
+// Score returns the classification error of g on the dataset d, the fraction of misclassified examples,
+// so that the scores of every learner are comparable.
+// Weights returns the learned weight vector, with w0 first.
+type Model interface {
+	Predict(x []float64) float64
+	Score(d Dataset) float64
+	Weights() []float64
+}
+
+// Learner is a model that can be fitted on a dataset.
+// Fit discards whatever the learner held before and learns from d.
+type Learner interface {
+	Model
+	Fit(d Dataset) error
+}
+
+// ClassificationError returns the fraction of examples of d for which sign(m.Predict(x)) != y.
+func ClassificationError(m Model, d Dataset) float64 {
+	if d.Len() == 0 {
+		return 0
+	}
+	misclassified := 0
+	for i := range d.X {
+		if linear.Sign(m.Predict(d.X[i])) != d.Y[i] {
+			misclassified++
+		}
+	}
+	return float64(misclassified) / float64(d.Len())
+}
+
+// ReadFile reads a dataset from a file with the following format:
+// x1 x2 ... y
+// x1 x2 ... y
+// with the values separated by spaces.
+func ReadFile(filename string) (Dataset, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return Dataset{}, err
+	}
+	defer file.Close()
+
+	var data [][]float64
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		cells := strings.Fields(scanner.Text())
+		if len(cells) == 0 {
+			continue
+		}
+		row := make([]float64, len(cells))
+		for i, cell := range cells {
+			if row[i], err = strconv.ParseFloat(cell, 64); err != nil {
+				return Dataset{}, fmt.Errorf("unable to parse line %d in file %s: %v", line, filename, err)
+			}
+		}
+		data = append(data, row)
+	}
+	if err := scanner.Err(); err != nil {
+		return Dataset{}, err
+	}
+	return FromData(data), nil
+}
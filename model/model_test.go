@@ -0,0 +1,36 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "data.dta")
+	content := "  -0.5   1.0e-01   1\n\n 0.25 -0.75 -1\n"
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d, err := ReadFile(filename)
+	if err != nil {
+		t.Fatalf("ReadFile returned error %v", err)
+	}
+	want := Dataset{X: [][]float64{{-0.5, 0.1}, {0.25, -0.75}}, Y: []int{1, -1}}
+	if !reflect.DeepEqual(d, want) {
+		t.Errorf("ReadFile == %v, want %v", d, want)
+	}
+
+	bad := filepath.Join(dir, "bad.dta")
+	if err := os.WriteFile(bad, []byte("0.5 x 1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadFile(bad); err == nil {
+		t.Errorf("ReadFile of a file with a bad value should return an error")
+	}
+	if _, err := ReadFile(filepath.Join(dir, "missing.dta")); err == nil {
+		t.Errorf("ReadFile of a missing file should return an error")
+	}
+}
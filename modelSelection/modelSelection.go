@@ -0,0 +1,184 @@
+package modelSelection
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/santiaago/caltechx.go/crossValidation"
+	"github.com/santiaago/caltechx.go/linreg"
+	"github.com/santiaago/caltechx.go/model"
+)
+
+// Candidate is a linear regression model: a transform of the inputs and a weight decay λ.
+type Candidate struct {
+	Name      string               // name of the transform.
+	Transform linreg.TransformFunc // nil means no transform.
+	Lambda    float64              // weight decay, 0 means no regularization.
+}
+
+func (c Candidate) String() string {
+	return fmt.Sprintf("%s, λ = %v", c.Name, c.Lambda)
+}
+
+// Learner returns a linear regression learning the candidate.
+func (c Candidate) Learner() model.Learner {
+	lr := linreg.NewLinearRegression()
+	lr.TransformFunction = c.Transform
+	lr.Lambda = c.Lambda
+	return lr
+}
+
+// Grid returns a candidate for every combination of transform and λ.
+// names[i] is the name of transforms[i].
+func Grid(names []string, transforms []linreg.TransformFunc, lambdas []float64) []Candidate {
+	if len(lambdas) == 0 {
+		lambdas = []float64{0}
+	}
+	var candidates []Candidate
+	for i, t := range transforms {
+		for _, lambda := range lambdas {
+			candidates = append(candidates, Candidate{Name: names[i], Transform: t, Lambda: lambda})
+		}
+	}
+	return candidates
+}
+
+// Method is the way candidates are validated.
+type Method int
+
+const (
+	Holdout         Method = iota // fit on the first TrainSize examples and validate on the rest.
+	CrossValidation               // validate every candidate on the same folds of CV.
+)
+
+func (m Method) String() string {
+	switch m {
+	case Holdout:
+		return "holdout"
+	case CrossValidation:
+		return "cross validation"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// ModelSelection holds all the information needed to select a candidate by validation.
+type ModelSelection struct {
+	Method    Method                           // validation method.
+	TrainSize int                              // number of training examples with the holdout method.
+	CV        *crossValidation.CrossValidation // cross validation used with the CrossValidation method.
+}
+
+// NewModelSelection is a constructor of a basic model selection:
+// Method = CrossValidation
+// CV = 10-fold cross validation
+func NewModelSelection() *ModelSelection {
+	ms := ModelSelection{}
+	ms.Method = CrossValidation
+	ms.CV = crossValidation.NewCrossValidation()
+	return &ms
+}
+
+// Row holds the validation errors of a candidate.
+type Row struct {
+	Rank      int
+	Candidate Candidate
+	Ein       float64 // in sample error, averaged over the folds with cross validation.
+	EVal      float64 // validation error, ECV with cross validation.
+	StdDev    float64 // standard deviation of the validation errors of the folds, 0 with holdout.
+}
+
+// Result holds the candidates ranked by validation error and the winner retrained on all the data.
+type Result struct {
+	Rows  []Row                    // rows sorted by increasing EVal, ties keep the order of the candidates.
+	Best  Candidate                // candidate with the smallest EVal.
+	Model *linreg.LinearRegression // best candidate fitted on all the data.
+}
+
+// Select validates every candidate on the dataset d, ranks them and retrains the best one on d.
+func (ms *ModelSelection) Select(candidates []Candidate, d model.Dataset) (*Result, error) {
+	if len(candidates) == 0 {
+		return nil, errors.New("model selection needs at least one candidate")
+	}
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	var folds []crossValidation.Fold
+	if ms.Method == CrossValidation {
+		// every candidate is validated on the same folds.
+		var err error
+		if folds, err = ms.CV.Split(d); err != nil {
+			return nil, err
+		}
+	}
+	rows := make([]Row, len(candidates))
+	for i, c := range candidates {
+		rows[i].Candidate = c
+		var err error
+		switch ms.Method {
+		case Holdout:
+			err = ms.holdout(&rows[i], d)
+		case CrossValidation:
+			var res *crossValidation.Result
+			if res, err = crossValidation.Evaluate(c.Learner, d, folds); err == nil {
+				rows[i].Ein, rows[i].EVal, rows[i].StdDev = res.Ein, res.ECV, res.StdDev
+			}
+		default:
+			err = errors.New("unknown validation method")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%v: %v", c, err)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].EVal < rows[j].EVal })
+	for i := range rows {
+		rows[i].Rank = i + 1
+	}
+
+	res := Result{Rows: rows, Best: rows[0].Candidate}
+	res.Model = res.Best.Learner().(*linreg.LinearRegression)
+	if err := res.Model.Fit(d); err != nil {
+		return nil, fmt.Errorf("retraining %v: %v", res.Best, err)
+	}
+	return &res, nil
+}
+
+// holdout fits the candidate of row on the first TrainSize examples of d and validates it on the rest.
+func (ms *ModelSelection) holdout(row *Row, d model.Dataset) error {
+	if ms.TrainSize <= 0 || ms.TrainSize >= d.Len() {
+		return fmt.Errorf("holdout train size should be between 1 and %d, got %d", d.Len()-1, ms.TrainSize)
+	}
+	train := d.Subset(indexRange(0, ms.TrainSize))
+	validation := d.Subset(indexRange(ms.TrainSize, d.Len()))
+	l := row.Candidate.Learner()
+	if err := l.Fit(train); err != nil {
+		return err
+	}
+	row.Ein = l.Score(train)
+	row.EVal = l.Score(validation)
+	return nil
+}
+
+// indexRange returns the indexes in [from : to).
+func indexRange(from, to int) []int {
+	indexes := make([]int, to-from)
+	for i := range indexes {
+		indexes[i] = from + i
+	}
+	return indexes
+}
+
+// WriteTable writes the ranked rows to w as a markdown table.
+func WriteTable(w io.Writer, rows []Row) error {
+	if _, err := fmt.Fprintln(w, "| rank | transform | λ | Ein | Eval | stddev |\n|---:|---|---:|---:|---:|---:|"); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if _, err := fmt.Fprintf(w, "| %d | %s | %v | %.5f | %.5f | %.5f |\n",
+			row.Rank, row.Candidate.Name, row.Candidate.Lambda, row.Ein, row.EVal, row.StdDev); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package modelSelection
+
+import (
+	"bytes"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/santiaago/caltechx.go/linreg"
+	"github.com/santiaago/caltechx.go/model"
+)
+
+// circle returns the points of a grid over [-0.9 : 0.9]^2 labeled +1 outside the circle x1^2 + x2^2 = 0.5.
+func circle() model.Dataset {
+	var d model.Dataset
+	for i := 0; i < 8; i++ {
+		for j := 0; j < 8; j++ {
+			x1, x2 := -0.9+float64(i)*1.8/7, -0.9+float64(j)*1.8/7
+			y := -1
+			if x1*x1+x2*x2 > 0.5 {
+				y = 1
+			}
+			d.X = append(d.X, []float64{x1, x2})
+			d.Y = append(d.Y, y)
+		}
+	}
+	return d
+}
+
+func identity(x []float64) []float64 {
+	return x
+}
+
+func squares(x []float64) []float64 {
+	return []float64{x[0], x[1], x[2], x[1] * x[1], x[2] * x[2]}
+}
+
+func TestSelect(t *testing.T) {
+	candidates := Grid([]string{"identity", "squares"}, []linreg.TransformFunc{identity, squares}, []float64{0, 0.1})
+	if len(candidates) != 4 {
+		t.Fatalf("Grid returned %d candidates, want 4", len(candidates))
+	}
+	for _, method := range []Method{CrossValidation, Holdout} {
+		ms := NewModelSelection()
+		ms.Method = method
+		ms.TrainSize = 40
+		ms.CV.K = 4
+		ms.CV.Rand = rand.New(rand.NewSource(1))
+		res, err := ms.Select(candidates, circle())
+		if err != nil {
+			t.Fatalf("%v: Select returned error %v", method, err)
+		}
+		if res.Best.Name != "squares" {
+			t.Errorf("%v: best candidate is %v, want squares", method, res.Best)
+		}
+		for i, row := range res.Rows {
+			if row.Rank != i+1 || (i > 0 && row.EVal < res.Rows[i-1].EVal) {
+				t.Errorf("%v: rows are not ranked by EVal: %+v", method, res.Rows)
+				break
+			}
+		}
+		if res.Model.Lambda != res.Best.Lambda {
+			t.Errorf("%v: retrained model has λ = %v, want %v", method, res.Model.Lambda, res.Best.Lambda)
+		}
+		if s := res.Model.Score(circle()); s != res.Model.Ein() {
+			t.Errorf("%v: retrained model scores %v on the data, want its Ein %v", method, s, res.Model.Ein())
+		}
+
+		var buf bytes.Buffer
+		if err := WriteTable(&buf, res.Rows); err != nil {
+			t.Fatalf("WriteTable returned error %v", err)
+		}
+		if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 2+len(candidates) {
+			t.Errorf("%v: WriteTable wrote %d lines, want %d", method, len(lines), 2+len(candidates))
+		}
+	}
+}
+
+func TestSelectErrors(t *testing.T) {
+	ms := NewModelSelection()
+	if _, err := ms.Select(nil, circle()); err == nil {
+		t.Errorf("Select without candidates should return an error")
+	}
+	ms.Method = Holdout
+	ms.TrainSize = 64
+	if _, err := ms.Select([]Candidate{{Name: "identity"}}, circle()); err == nil {
+		t.Errorf("Select with a holdout of the whole data set should return an error")
+	}
+}
+
+func TestCandidateLearner(t *testing.T) {
+	lr := Candidate{Name: "squares", Transform: squares, Lambda: 0.5}.Learner().(*linreg.LinearRegression)
+	if lr.Lambda != 0.5 {
+		t.Errorf("Learner() has Lambda = %v, want 0.5", lr.Lambda)
+	}
+}
@@ -0,0 +1,137 @@
+package pipeline
+
+import (
+	"math"
+
+	"github.com/santiaago/caltechx.go/linreg"
+	"github.com/santiaago/caltechx.go/model"
+)
+
+// Step is a preprocessing step of a pipeline working on raw input vectors, without x0.
+// Fit learns whatever the step needs from the training inputs,
+// Transform applies it to any input.
+// The scalers of the scaling package are steps.
+type Step interface {
+	Fit(x [][]float64) error
+	Transform(x []float64) ([]float64, error)
+}
+
+// transformStep is a step applying a TransformFunc, it learns nothing.
+type transformStep struct {
+	f linreg.TransformFunc
+}
+
+// Transform returns a step applying the feature transform f.
+// f takes and returns vectors starting with x0 = 1, as every TransformFunc.
+func Transform(f linreg.TransformFunc) Step {
+	return transformStep{f}
+}
+
+func (t transformStep) Fit(x [][]float64) error {
+	return nil
+}
+
+func (t transformStep) Transform(x []float64) ([]float64, error) {
+	return t.f(append([]float64{float64(1)}, x...))[1:], nil
+}
+
+// Pipeline chains preprocessing steps and a final learner.
+// It is fitted once on a dataset and then applies the same steps to any new input,
+// so it can be used wherever a model.Learner is expected.
+type Pipeline struct {
+	Steps   []Step        // steps applied in order to the inputs.
+	Learner model.Learner // learner fitted on the inputs transformed by all the steps.
+}
+
+// New is a constructor of a pipeline applying steps in order before learner.
+func New(learner model.Learner, steps ...Step) *Pipeline {
+	p := Pipeline{}
+	p.Steps = steps
+	p.Learner = learner
+	return &p
+}
+
+// Fit fits every step on the inputs of d transformed by the previous steps,
+// and then fits the learner on the transformed dataset.
+func (p *Pipeline) Fit(d model.Dataset) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	x := d.X
+	for _, s := range p.Steps {
+		if err := s.Fit(x); err != nil {
+			return err
+		}
+		z := make([][]float64, len(x))
+		for n := range x {
+			var err error
+			if z[n], err = s.Transform(x[n]); err != nil {
+				return err
+			}
+		}
+		x = z
+	}
+	return p.Learner.Fit(model.Dataset{X: x, Y: d.Y})
+}
+
+// Transform returns the input x transformed by all the steps.
+func (p *Pipeline) Transform(x []float64) ([]float64, error) {
+	for _, s := range p.Steps {
+		var err error
+		if x, err = s.Transform(x); err != nil {
+			return nil, err
+		}
+	}
+	return x, nil
+}
+
+// TransformDataset returns the dataset d with its inputs transformed by all the steps.
+func (p *Pipeline) TransformDataset(d model.Dataset) (model.Dataset, error) {
+	t := model.Dataset{X: make([][]float64, d.Len()), Y: d.Y}
+	for n := range d.X {
+		var err error
+		if t.X[n], err = p.Transform(d.X[n]); err != nil {
+			return model.Dataset{}, err
+		}
+	}
+	return t, nil
+}
+
+// Predict returns the prediction of the learner on the transformed input x,
+// NaN if a step cannot transform x.
+func (p *Pipeline) Predict(x []float64) float64 {
+	z, err := p.Transform(x)
+	if err != nil {
+		return math.NaN()
+	}
+	return p.Learner.Predict(z)
+}
+
+// Score returns the error of the learner on the transformed dataset d,
+// NaN if a step cannot transform d.
+func (p *Pipeline) Score(d model.Dataset) float64 {
+	t, err := p.TransformDataset(d)
+	if err != nil {
+		return math.NaN()
+	}
+	return p.Learner.Score(t)
+}
+
+// EoutFromFile returns the error of the learner on the dataset read from filename,
+// transformed by all the steps. See model.ReadFile for the format of the file.
+func (p *Pipeline) EoutFromFile(filename string) (float64, error) {
+	d, err := model.ReadFile(filename)
+	if err != nil {
+		return 0, err
+	}
+	t, err := p.TransformDataset(d)
+	if err != nil {
+		return 0, err
+	}
+	return p.Learner.Score(t), nil
+}
+
+// Weights returns the weights of the learner, in the space of the transformed inputs.
+func (p *Pipeline) Weights() []float64 {
+	return p.Learner.Weights()
+}
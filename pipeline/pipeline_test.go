@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"math"
+	"testing"
+
+	"github.com/santiaago/caltechx.go/linreg"
+	"github.com/santiaago/caltechx.go/model"
+	"github.com/santiaago/caltechx.go/scaling"
+)
+
+func quadratic(x []float64) []float64 {
+	return []float64{x[0], x[1], x[2], x[1] * x[1], x[2] * x[2], x[1] * x[2]}
+}
+
+func TestPipelineMatchesSteps(t *testing.T) {
+	train, err := model.ReadFile("../data/in.dta")
+	if err != nil {
+		t.Fatal(err)
+	}
+	test, err := model.ReadFile("../data/out.dta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := New(linreg.NewLinearRegression(), scaling.NewScaler(scaling.ZScore), Transform(quadratic))
+	if err := p.Fit(train); err != nil {
+		t.Fatalf("Fit returned error %v", err)
+	}
+
+	// the same steps by hand.
+	scaler := scaling.NewScaler(scaling.ZScore)
+	if err := scaler.Fit(train.X); err != nil {
+		t.Fatal(err)
+	}
+	byHand := func(d model.Dataset) model.Dataset {
+		t.Helper()
+		scaled, err := scaler.TransformDataset(d)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for n, x := range scaled.X {
+			scaled.X[n] = quadratic(append([]float64{1}, x...))[1:]
+		}
+		return scaled
+	}
+	lr := linreg.NewLinearRegression()
+	if err := lr.Fit(byHand(train)); err != nil {
+		t.Fatal(err)
+	}
+
+	weights := p.Weights()
+	for i, w := range lr.Weights() {
+		if weights[i] != w {
+			t.Fatalf("pipeline weights == %v, want %v", weights, lr.Weights())
+		}
+	}
+	testByHand := byHand(test)
+	for n, x := range test.X {
+		if got, want := p.Predict(x), lr.Predict(testByHand.X[n]); got != want {
+			t.Errorf("Predict(%v) == %v, want %v", n, got, want)
+		}
+	}
+	if got, want := p.Score(test), lr.Score(testByHand); got != want {
+		t.Errorf("Score() == %v, want %v", got, want)
+	}
+	if got, err := p.EoutFromFile("../data/out.dta"); err != nil || got != lr.Score(testByHand) {
+		t.Errorf("EoutFromFile() == %v, %v, want %v", got, err, lr.Score(testByHand))
+	}
+
+	// inputs the scaler was not fitted on.
+	if got := p.Predict([]float64{1, 2, 3}); !math.IsNaN(got) {
+		t.Errorf("Predict of a vector of another size == %v, want NaN", got)
+	}
+	if _, err := p.EoutFromFile("missing.dta"); err == nil {
+		t.Errorf("EoutFromFile of a missing file should return an error")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/santiaago/caltechx.go/linear"
+	"github.com/santiaago/caltechx.go/model"
 	"math/rand"
 	"time"
 )
@@ -17,7 +18,8 @@ type PLA struct {
 	Xn             []Point                    // data set of random points (uniformly in interval)
 	Yn             []int                      // output, evaluation of each Xn based on linear function defined by RandLinearVars
 	Wn             Point                      // weight vector initialized at zeros.
-	H              func(x Point, w Point) int // hypothesis function of the pla algorithm
+	H              func(x Point, w Point) int // hypothesis function of the pla algorithm, h(x) = sign(w'x) when nil.
+	IterationLimit int                        // maximum number of weight updates of Converge, no limit when 0.
 }
 
 // Hypothesis function h is the hypothesis of the perceptron algorithm.
@@ -39,14 +41,24 @@ func h(x Point, w Point) int {
 // N = 10
 // Interval [-1 : 1]
 // H = h(x) = sign(w'x)
+// IterationLimit = 100000
 func NewPLA() *PLA {
 	pla := PLA{}
 	pla.N = 10
 	pla.Interval = linear.Interval{-1, 1}
 	pla.H = h
+	pla.IterationLimit = 100000
 	return &pla
 }
 
+// hypothesis returns H, or h(x) = sign(w'x) when H is nil.
+func (pla *PLA) hypothesis() func(x Point, w Point) int {
+	if pla.H == nil {
+		return h
+	}
+	return pla.H
+}
+
 // Initialize will set up the PLA structure with the following:
 // - the random linear function
 // - vector Xn with X0 at 1 and X1 and X2 random point in the defined input space.
@@ -83,7 +95,7 @@ func (pla *PLA) updateWeight(n int) {
 func (pla *PLA) extractMisclassifiedIndexes() []int {
 	var set []int
 	for i := 0; i < len(pla.Xn); i++ {
-		if pla.H(pla.Xn[i], pla.Wn) != pla.Yn[i] {
+		if pla.hypothesis()(pla.Xn[i], pla.Wn) != pla.Yn[i] {
 			set = append(set, i)
 		}
 	}
@@ -107,11 +119,11 @@ func (pla *PLA) randMisclassifiedPoint() (int, error) {
 // Converge will run the PLA algorithm:
 // 1 - pick a misclassified point
 // 2 - update the weight vector accordingly
-// stop when no more misclassified points.
+// stop when no more misclassified points or after IterationLimit updates.
 // Returns the number of iterations needed to converge.
 func (pla *PLA) Converge() int {
 	iterations := 0
-	for {
+	for pla.IterationLimit <= 0 || iterations < pla.IterationLimit {
 		// pick a misclassified point and update the weight vector accordingly
 		if randPoint, err := pla.randMisclassifiedPoint(); err == nil {
 			pla.updateWeight(randPoint)
@@ -124,6 +136,11 @@ func (pla *PLA) Converge() int {
 	return iterations
 }
 
+// Ein is the fraction of in sample points misclassified by the weight vector Wn.
+func (pla *PLA) Ein() float64 {
+	return float64(len(pla.extractMisclassifiedIndexes())) / float64(len(pla.Xn))
+}
+
 // Disagreement will measure the out of sample error of the g function.
 // The mesurment is done by generating 1000 out of sample data points and comparing the
 // target function and the 'g' (learned) function.
@@ -140,13 +157,53 @@ func (pla *PLA) Disagreement() float64 {
 		oX[1] = pla.Interval.RandFloat()
 		oX[2] = pla.Interval.RandFloat()
 		oY = evaluate(pla.TargetFunction, oX)
-		if pla.H(oX, pla.Wn) != oY {
+		if pla.hypothesis()(oX, pla.Wn) != oY {
 			numError++
 		}
 	}
 	return float64(numError) / float64(outOfSample)
 }
 
+// Fit sets Xn and Yn from the dataset d of 2 dimentional points, resets Wn to zero and converges.
+// It returns an error if some points are still misclassified after IterationLimit updates,
+// which happens when the dataset is not linearly separable.
+func (pla *PLA) Fit(d model.Dataset) error {
+	if err := d.Validate(); err != nil {
+		return err
+	}
+	pla.N = d.Len()
+	pla.Xn = make([]Point, pla.N)
+	pla.Yn = make([]int, pla.N)
+	pla.Wn = Point{}
+	for i := range d.X {
+		if len(d.X[i]) != 2 {
+			return errors.New("pla only supports 2 dimentional points")
+		}
+		pla.Xn[i] = Point{float64(1), d.X[i][0], d.X[i][1]}
+		pla.Yn[i] = d.Y[i]
+	}
+	pla.Converge()
+	if len(pla.extractMisclassifiedIndexes()) > 0 {
+		return errors.New("pla iteration limit reached before converging, the dataset might not be linearly separable")
+	}
+	return nil
+}
+
+// Predict returns h(x) = sign(w'x) with x0 = 1 added to the 2 dimentional point x.
+func (pla *PLA) Predict(x []float64) float64 {
+	return float64(pla.hypothesis()(Point{float64(1), x[0], x[1]}, pla.Wn))
+}
+
+// Score returns the fraction of points of d misclassified by Wn.
+func (pla *PLA) Score(d model.Dataset) float64 {
+	return model.ClassificationError(pla, d)
+}
+
+// Weights returns a copy of the weight vector Wn.
+func (pla *PLA) Weights() []float64 {
+	return append([]float64{}, pla.Wn[:]...)
+}
+
 // print will display the current random function and the current data hold by vectors Xn, Yn and Wn.
 func (pla *PLA) print() {
 	pla.TargetVars.Print()
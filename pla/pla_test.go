@@ -0,0 +1,25 @@
+package pla
+
+import (
+	"testing"
+
+	"github.com/santiaago/caltechx.go/model"
+)
+
+func TestFit(t *testing.T) {
+	// the zero value uses h(x) = sign(w'x) without iteration limit.
+	var p PLA
+	separable := model.Dataset{X: [][]float64{{-1, -1}, {-0.5, 0.5}, {0.5, -0.5}, {1, 1}}, Y: []int{-1, -1, 1, 1}}
+	if err := p.Fit(separable); err != nil {
+		t.Fatalf("Fit returned error %v", err)
+	}
+	if s := p.Score(separable); s != 0 {
+		t.Errorf("Score() == %v, want 0", s)
+	}
+
+	xor := model.Dataset{X: [][]float64{{-1, -1}, {-1, 1}, {1, -1}, {1, 1}}, Y: []int{-1, 1, 1, -1}}
+	p.IterationLimit = 100
+	if err := p.Fit(xor); err == nil {
+		t.Errorf("Fit on XOR should return an error")
+	}
+}
@@ -0,0 +1,143 @@
+package scaling
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/santiaago/caltechx.go/model"
+)
+
+// Method is the way features are rescaled.
+type Method int
+
+const (
+	ZScore Method = iota // (x - mean) / standard deviation.
+	MinMax               // (x - min) / (max - min), in [0 : 1] on the training data.
+	MaxAbs               // x / max|x|, in [-1 : 1] on the training data.
+)
+
+func (m Method) String() string {
+	switch m {
+	case ZScore:
+		return "z-score"
+	case MinMax:
+		return "min-max"
+	case MaxAbs:
+		return "max-abs"
+	}
+	return fmt.Sprintf("Method(%d)", int(m))
+}
+
+// Scaler rescales every feature i as (xi - Offset[i]) / Scale[i].
+// Offset and Scale are learned by Fit on the training data and then applied unchanged
+// to validation and out of sample data.
+// Features that are constant on the training data get a Scale of 1.
+type Scaler struct {
+	Method Method
+	Offset []float64
+	Scale  []float64
+}
+
+// NewScaler is a constructor of a scaler using the given method.
+func NewScaler(m Method) *Scaler {
+	s := Scaler{}
+	s.Method = m
+	return &s
+}
+
+// Fit learns Offset and Scale from the feature vectors x.
+func (s *Scaler) Fit(x [][]float64) error {
+	if len(x) == 0 {
+		return errors.New("scaler needs at least one vector to fit")
+	}
+	d := len(x[0])
+	s.Offset = make([]float64, d)
+	s.Scale = make([]float64, d)
+	for i := 0; i < d; i++ {
+		column := make([]float64, len(x))
+		for n := range x {
+			if len(x[n]) != d {
+				return errors.New("scaler needs vectors of the same size")
+			}
+			column[n] = x[n][i]
+		}
+		switch s.Method {
+		case ZScore:
+			mean := float64(0)
+			for _, v := range column {
+				mean += v
+			}
+			mean /= float64(len(column))
+			variance := float64(0)
+			for _, v := range column {
+				variance += (v - mean) * (v - mean)
+			}
+			s.Offset[i] = mean
+			s.Scale[i] = math.Sqrt(variance / float64(len(column)))
+		case MinMax:
+			min, max := math.Inf(1), math.Inf(-1)
+			for _, v := range column {
+				min = math.Min(min, v)
+				max = math.Max(max, v)
+			}
+			s.Offset[i] = min
+			s.Scale[i] = max - min
+		case MaxAbs:
+			for _, v := range column {
+				s.Scale[i] = math.Max(s.Scale[i], math.Abs(v))
+			}
+		default:
+			return errors.New("unknown scaling method")
+		}
+		if s.Scale[i] == 0 {
+			s.Scale[i] = float64(1)
+		}
+	}
+	return nil
+}
+
+// Transform returns the rescaled copy of the feature vector x.
+// It returns an error if the scaler is not fitted or x does not have the size of the training vectors.
+func (s *Scaler) Transform(x []float64) ([]float64, error) {
+	if len(s.Offset) == 0 || len(s.Scale) != len(s.Offset) {
+		return nil, errors.New("scaler is not fitted")
+	}
+	if len(x) != len(s.Offset) {
+		return nil, fmt.Errorf("scaler was fitted on vectors of size %d, not %d", len(s.Offset), len(x))
+	}
+	z := make([]float64, len(x))
+	for i := range x {
+		z[i] = (x[i] - s.Offset[i]) / s.Scale[i]
+	}
+	return z, nil
+}
+
+// TransformAll returns the rescaled copies of the feature vectors x.
+func (s *Scaler) TransformAll(x [][]float64) ([][]float64, error) {
+	z := make([][]float64, len(x))
+	for n := range x {
+		var err error
+		if z[n], err = s.Transform(x[n]); err != nil {
+			return nil, err
+		}
+	}
+	return z, nil
+}
+
+// FitTransform learns Offset and Scale from x and returns the rescaled copies of x.
+func (s *Scaler) FitTransform(x [][]float64) ([][]float64, error) {
+	if err := s.Fit(x); err != nil {
+		return nil, err
+	}
+	return s.TransformAll(x)
+}
+
+// TransformDataset returns a copy of the dataset d with rescaled inputs.
+func (s *Scaler) TransformDataset(d model.Dataset) (model.Dataset, error) {
+	x, err := s.TransformAll(d.X)
+	if err != nil {
+		return model.Dataset{}, err
+	}
+	return model.Dataset{X: x, Y: append([]int{}, d.Y...)}, nil
+}
@@ -0,0 +1,64 @@
+package scaling
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMethods(t *testing.T) {
+	// the first column is constant, the second one has mean 2 and standard deviation 1.
+	x := [][]float64{{3, 1}, {3, 1}, {3, 3}, {3, 3}}
+	tests := []struct {
+		method Method
+		want   []float64 // rescaled second column.
+		first  float64   // rescaled constant column.
+	}{
+		{ZScore, []float64{-1, -1, 1, 1}, 0},
+		{MinMax, []float64{0, 0, 1, 1}, 0},
+		{MaxAbs, []float64{float64(1) / 3, float64(1) / 3, 1, 1}, 1},
+	}
+	for _, tt := range tests {
+		s := NewScaler(tt.method)
+		z, err := s.FitTransform(x)
+		if err != nil {
+			t.Fatalf("%v: FitTransform returned error %v", tt.method, err)
+		}
+		for n := range z {
+			if math.IsNaN(z[n][0]) || math.Abs(z[n][0]-tt.first) > 1e-12 || math.Abs(z[n][1]-tt.want[n]) > 1e-12 {
+				t.Errorf("%v: FitTransform == %v, want first column %v and second column %v", tt.method, z, tt.first, tt.want)
+				break
+			}
+		}
+	}
+
+	// a constant zero column has no range, no deviation and no maximum.
+	for _, m := range []Method{ZScore, MinMax, MaxAbs} {
+		s := NewScaler(m)
+		if err := s.Fit([][]float64{{0}, {0}}); err != nil || s.Scale[0] != 1 {
+			t.Errorf("%v: Fit on a zero column gives Scale %v, %v, want 1", m, s.Scale, err)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	s := NewScaler(MinMax)
+	if _, err := s.Transform([]float64{1}); err == nil {
+		t.Errorf("Transform with an unfitted scaler should return an error")
+	}
+	if err := s.Fit([][]float64{{1}, {5}, {9}}); err != nil {
+		t.Fatalf("Fit returned error %v", err)
+	}
+	// out of sample points use the training statistics and can leave [0 : 1].
+	if z, err := s.Transform([]float64{25}); err != nil || z[0] != 3 {
+		t.Errorf("Transform(25) == %v, %v, want [3]", z, err)
+	}
+	if s.Offset[0] != 1 || s.Scale[0] != 8 {
+		t.Errorf("Transform changed Offset to %v and Scale to %v", s.Offset, s.Scale)
+	}
+	if _, err := s.Transform([]float64{1, 2}); err == nil {
+		t.Errorf("Transform of a vector of another size should return an error")
+	}
+	if _, err := s.TransformAll([][]float64{{1}, {1, 2}}); err == nil {
+		t.Errorf("TransformAll with a vector of another size should return an error")
+	}
+}
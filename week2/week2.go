@@ -2,34 +2,16 @@ package main
 
 import (
 	"fmt"
-	"math"
-	"math/rand"
 	"runtime"
-	"sync"
 	"time"
 
+	"github.com/santiaago/caltechx.go/hoeffding"
+	"github.com/santiaago/caltechx.go/learningCurve"
 	"github.com/santiaago/caltechx.go/linreg"
 	"github.com/santiaago/caltechx.go/pla"
 	"github.com/santiaago/ml"
 )
 
-func sum(a []int) int {
-	r := 0
-	for _, v := range a {
-		r += v
-	}
-	return r
-}
-
-type experiment struct {
-	NRuns    int
-	NCoins   int
-	NFlips   int
-	AvgV1    float64
-	AvgVRand float64
-	AvgVMin  float64
-}
-
 // measure will measure the time taken by function f to run and display it.
 func measure(f func(), name string) {
 	start := time.Now()
@@ -39,91 +21,16 @@ func measure(f func(), name string) {
 }
 
 func q1() {
-	e := experiment{NRuns: 100000, NCoins: 1000, NFlips: 10}
-	r := rand.New(rand.NewSource(time.Now().UnixNano()))
-
-	var sumV1, sumVRand, sumVMin float64
-	for run := 0; run < e.NRuns; run++ {
-		coins := make([][]int, e.NCoins)
-		for i, _ := range coins {
-			coins[i] = make([]int, e.NFlips)
-			for j := 0; j < len(coins[i]); j++ {
-				coins[i][j] = r.Intn(2)
-			}
-		}
-		v1 := float64(float64(sum(coins[0])) / float64(e.NFlips))
-		randIndex := r.Intn(e.NCoins - 1)
-		vRand := float64(float64(sum(coins[randIndex])) / float64(e.NFlips))
-		vMin := math.Inf(1)
-		for i, _ := range coins {
-			vCurrent := float64(float64(sum(coins[i])) / float64(e.NFlips))
-			if vCurrent < vMin {
-				vMin = vCurrent
-			}
-		}
-		sumV1 += v1
-		sumVRand += vRand
-		sumVMin += vMin
-	}
-	e.AvgV1 = sumV1 / float64(e.NRuns)
-	e.AvgVRand = sumVRand / float64(e.NRuns)
-	e.AvgVMin = sumVMin / float64(e.NRuns)
-	fmt.Printf("V1: %4.2f\nVRand: %4.2f\nVMin: %4.2f\n", e.AvgV1, e.AvgVRand, e.AvgVMin)
-}
-
-func q1cc() {
-	e := experiment{NRuns: 100000, NCoins: 1000, NFlips: 10}
-
-	var wg sync.WaitGroup
-
-	chanV1 := make(chan float64, e.NRuns)
-	chanVRand := make(chan float64, e.NRuns)
-	chanVMin := make(chan float64, e.NRuns)
-
-	for run := 0; run < e.NRuns; run++ {
-		wg.Add(1)
-		go func() {
-			r := rand.New(rand.NewSource(time.Now().UnixNano()))
-			coins := make([][]int, e.NCoins)
-			for i, _ := range coins {
-				coins[i] = make([]int, e.NFlips)
-				for j := 0; j < len(coins[i]); j++ {
-					coins[i][j] = r.Intn(2)
-				}
-			}
-			v1 := float64(float64(sum(coins[0])) / float64(e.NFlips))
-			randIndex := r.Intn(e.NCoins - 1)
-			vRand := float64(float64(sum(coins[randIndex])) / float64(e.NFlips))
-			vMin := math.Inf(1)
-			for i, _ := range coins {
-				vCurrent := float64(float64(sum(coins[i])) / float64(e.NFlips))
-				if vCurrent < vMin {
-					vMin = vCurrent
-				}
-			}
-			chanV1 <- v1
-			chanVRand <- vRand
-			chanVMin <- vMin
-			wg.Done()
-		}()
+	e := hoeffding.NewCoinExperiment()
+	res, err := e.Run()
+	if err != nil {
+		fmt.Println(err)
+		return
 	}
-
-	wg.Wait()
-	var sumV1, sumVRand, sumVMin float64
-	for i := 0; i < e.NRuns; i++ {
-		sumV1 += <-chanV1
-	}
-	for i := 0; i < e.NRuns; i++ {
-		sumVRand += <-chanVRand
-	}
-	for i := 0; i < e.NRuns; i++ {
-		sumVMin += <-chanVMin
+	fmt.Printf("V1: %4.2f\nVRand: %4.2f\nVMin: %4.2f\n", res.Average(res.V1), res.Average(res.VRand), res.Average(res.VMin))
+	for _, row := range res.Deviations([]float64{0, 0.1, 0.2, 0.3, 0.4, 0.5}) {
+		fmt.Printf("P[|v - mu| > %3.1f]: V1: %5.3f VRand: %5.3f VMin: %5.3f Hoeffding: %5.3f\n", row.E, row.V1, row.VRand, row.VMin, row.Hoeffding)
 	}
-
-	e.AvgV1 = sumV1 / float64(e.NRuns)
-	e.AvgVRand = sumVRand / float64(e.NRuns)
-	e.AvgVMin = sumVMin / float64(e.NRuns)
-	fmt.Printf("V1: %4.2f\nVRand: %4.2f\nVMin: %4.2f\n", e.AvgV1, e.AvgVRand, e.AvgVMin)
 }
 
 func q5() {
@@ -302,12 +209,36 @@ func q9() {
 	fmt.Printf("average Eout of the hypothesis learn through linear regression with noise of %4.2f is %4.3f\n", linreg.Noise, AvgEout)
 }
 
+// learningCurves prints the learning curves of the perceptron, linear regression and logistic regression on random linear targets.
+func learningCurves() {
+	ns := []int{10, 20, 50, 100}
+	generate := learningCurve.LinearTarget(1000)
+	curves := []struct {
+		name  string
+		trial learningCurve.Trial
+	}{
+		{"PLA", learningCurve.PLATrial(generate)},
+		{"Linear regression", learningCurve.LinearRegressionTrial(generate)},
+		{"Logistic regression", learningCurve.LogisticRegressionTrial(generate)},
+	}
+	for _, curve := range curves {
+		points, err := learningCurve.Generate(curve.trial, ns, 100)
+		if err != nil {
+			fmt.Printf("%s: %v\n", curve.name, err)
+			continue
+		}
+		fmt.Printf("learning curve of %s:\n", curve.name)
+		for _, p := range points {
+			fmt.Printf("N = %3d Ein = %5.3f (+/- %5.3f) Eout = %5.3f (+/- %5.3f)\n", p.N, p.Ein, p.EinStdErr, p.Eout, p.EoutStdErr)
+		}
+	}
+}
+
 func main() {
 	fmt.Println("Num CPU: ", runtime.NumCPU())
 	runtime.GOMAXPROCS(runtime.NumCPU())
 	fmt.Println("week 2")
 	//measure(q1, "q1")
-	//measure(q1cc, "q1 concurrent")
 	fmt.Println("1")
 	fmt.Println("2")
 	fmt.Println("3")
@@ -322,4 +253,5 @@ func main() {
 	measure(q9, "q9")
 	fmt.Println("9")
 	fmt.Println("10")
+	measure(learningCurves, "learning curves")
 }
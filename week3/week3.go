@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"github.com/santiaago/caltechx.go/dichotomy"
+	"github.com/santiaago/caltechx.go/generalizationError"
 	"github.com/santiaago/caltechx.go/hoeffding"
 	"runtime"
 	"time"
@@ -45,6 +47,50 @@ func q3() {
 	}
 }
 
+// compareInequalities shows the number of examples needed by each concentration inequality
+// for a probability of at most 0.03 with epsilon = 0.05
+func compareInequalities() {
+	hoef := hoeffding.NewHoeffdingExperiment()
+	for _, m := range []int{1, 10, 100} {
+		hoef.M = m
+		for _, i := range hoeffding.Inequalities {
+			n, err := hoef.SolveN(i)
+			if err != nil {
+				fmt.Printf("%v: %v\n", i, err)
+				continue
+			}
+			fmt.Printf("%v inequality for M = %v needs N = %v\n", i, m, n)
+		}
+	}
+}
+
+// breakPoints estimates the growth function of some hypothesis sets by counting dichotomies
+// and compares it with the exact growth function.
+func breakPoints() {
+	sets := []struct {
+		name  string
+		h     dichotomy.HypothesisSet
+		d     int
+		exact generalizationError.GrowthFunction
+	}{
+		{"positive rays", dichotomy.PositiveRays{}, 1, generalizationError.PositiveRays{}},
+		{"positive intervals", dichotomy.PositiveIntervals{}, 1, generalizationError.PositiveIntervals{}},
+		{"2D perceptron", dichotomy.Perceptron{}, 2, generalizationError.Perceptron2D()},
+	}
+	for _, set := range sets {
+		counter := dichotomy.NewCounter(set.h, dichotomy.UniformPoints(set.d, -1, 1))
+		estimate, err := counter.Estimate(10)
+		if err != nil {
+			fmt.Printf("%s: %v\n", set.name, err)
+			continue
+		}
+		for i, m := range estimate.M_H {
+			fmt.Printf("%s: m_H(%d) estimated = %d, exact = %v\n", set.name, i+1, m, set.exact.M(i+1))
+		}
+		fmt.Printf("%s: break point = %d, dvc = %d\n", set.name, estimate.BreakPoint, estimate.Dvc)
+	}
+}
+
 func main() {
 	fmt.Println("Num CPU: ", runtime.NumCPU())
 	runtime.GOMAXPROCS(runtime.NumCPU())
@@ -54,7 +100,9 @@ func main() {
 	measure(q2, "q2")
 	fmt.Println("2")
 	measure(q3, "q3")
+	measure(compareInequalities, "compare inequalities")
 	fmt.Println("3")
+	measure(breakPoints, "break points")
 	fmt.Println("4")
 	fmt.Println("5")
 	fmt.Println("6")
@@ -23,7 +23,20 @@ func q1() {
 	genErr.SetConfidence(0.95)
 	genErr.Epsilon = 0.05
 
-	fmt.Printf("The lower bound for a H with dvc = 10, confidence of 95 percent and epsilon of 0.05 is N = %v\n", genErr.LowerBound())
+	n, err := genErr.LowerBound()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("The lower bound for a H with dvc = 10, confidence of 95 percent and epsilon of 0.05 is N = %v\n", n)
+	for _, b := range generalizationError.Bounds {
+		s, err := genErr.SampleComplexity(b)
+		if err != nil {
+			fmt.Printf("%v: %v\n", b, err)
+			continue
+		}
+		fmt.Printf("%v: N = %v (found in %d iterations)\n", b, s.N, s.Iterations)
+	}
 }
 
 func q2() {
@@ -35,8 +48,16 @@ func q2() {
 	fmt.Println("For dvc = 50, delta = 0.05, N = 10000")
 	fmt.Printf("Original VC bound: \t\t\t%7.5f\n", genErr.VCBound(10000))
 	fmt.Printf("Rademacher Penalty Bound: \t\t%7.5f\n", genErr.RademacherPenaltyBound(10000))
-	fmt.Printf("Parrondo And Van Den Broek: \t\t%7.5f\n", genErr.ParrondoAndVanDenBroek(10000))
-	fmt.Printf("Devroye: \t\t\t\t%7.5f\n", genErr.DevroyeLog(10000))
+	if parrondo, err := genErr.ParrondoAndVanDenBroek(10000); err != nil {
+		fmt.Printf("Parrondo And Van Den Broek: \t\t%v\n", err)
+	} else {
+		fmt.Printf("Parrondo And Van Den Broek: \t\t%7.5f\n", parrondo)
+	}
+	if devroye, err := genErr.DevroyeLog(10000); err != nil {
+		fmt.Printf("Devroye: \t\t\t\t%v\n", err)
+	} else {
+		fmt.Printf("Devroye: \t\t\t\t%7.5f\n", devroye)
+	}
 }
 
 func q3() {
@@ -48,39 +69,48 @@ func q3() {
 	fmt.Println("For dvc = 50, delta = 0.05, N = 5")
 	fmt.Printf("Original VC bound: \t\t\t%7.5f\n", genErr.VCBound(5))
 	fmt.Printf("Rademacher Penalty Bound: \t\t%7.5f\n", genErr.RademacherPenaltyBound(5))
-	fmt.Printf("Parrondo And Van Den Broek: \t\t%7.5f\n", genErr.ParrondoAndVanDenBroek(5))
-	fmt.Printf("Devroye: \t\t\t\t%7.5f\n", genErr.Devroye(5))
+	if parrondo, err := genErr.ParrondoAndVanDenBroek(5); err != nil {
+		fmt.Printf("Parrondo And Van Den Broek: \t\t%v\n", err)
+	} else {
+		fmt.Printf("Parrondo And Van Den Broek: \t\t%7.5f\n", parrondo)
+	}
+	if devroye, err := genErr.DevroyeLog(5); err != nil {
+		fmt.Printf("Devroye: \t\t\t\t%v\n", err)
+	} else {
+		fmt.Printf("Devroye: \t\t\t\t%7.5f\n", devroye)
+	}
 }
 
 func q4() {
 	bav := biasAndVariance.NewBiasAndVariance()
-	bav.LearnLine()
-	fmt.Printf("h(x) = ax\n")
-	bav.Print()
+	bav.Quadrature = 20
+	learnAndPrint("h(x) = ax", bav, bav.LearnLine)
 }
 
 func q7() {
 	bav := biasAndVariance.NewBiasAndVariance()
-	bav.LearnConstant()
-	fmt.Printf("h(x) = b\n")
-	bav.Print()
+	learnAndPrint("h(x) = b", bav, bav.LearnConstant)
 
 	bav1 := biasAndVariance.NewBiasAndVariance()
 	bav1.ThroughOrigin = false
-	bav1.LearnLine()
-	fmt.Printf("h(x) = ax + b\n")
-	bav1.Print()
+	learnAndPrint("h(x) = ax + b", bav1, bav1.LearnLine)
 
 	bav2 := biasAndVariance.NewBiasAndVariance()
-	bav2.LearnQuadratic()
-	fmt.Printf("h(x) = ax^2\n")
-	bav2.Print()
+	learnAndPrint("h(x) = ax^2", bav2, bav2.LearnQuadratic)
 
 	bav3 := biasAndVariance.NewBiasAndVariance()
 	bav3.ThroughOrigin = false
-	bav3.LearnQuadratic()
-	fmt.Printf("h(x) = ax^2\n")
-	bav2.Print()
+	learnAndPrint("h(x) = ax^2 + b", bav3, bav3.LearnQuadratic)
+}
+
+// learnAndPrint runs learn and prints the bias and variance of bav, or the error returned by learn.
+func learnAndPrint(title string, bav *biasAndVariance.BiasAndVariance, learn func() error) {
+	fmt.Println(title)
+	if err := learn(); err != nil {
+		fmt.Println(err)
+		return
+	}
+	bav.Print()
 }
 
 func main() {
@@ -1,14 +1,23 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	GD "github.com/santiaago/caltechx.go/gradientDescent"
 	"github.com/santiaago/caltechx.go/linreg"
 	"github.com/santiaago/caltechx.go/logreg"
+	"github.com/santiaago/caltechx.go/model"
+	"github.com/santiaago/caltechx.go/scaling"
+	"log"
+	"math/rand"
+	"os"
 	"runtime"
 	"time"
 )
 
+// svg is the file compareDescents draws into.
+var svg = flag.String("svg", "", "file the paths of gradient and coordinate descent are drawn into, nothing is drawn when empty")
+
 // measure will measure the time taken by function f to run and display it.
 func measure(f func(), name string) {
 	start := time.Now()
@@ -30,7 +39,9 @@ func q5() {
 	gd.V = float64(1)
 	gd.Eta = float64(0.1)
 	gd.ErrorLimit = 10e-14
-	fmt.Println("iterations needed to fall under the limit, ", gd.E())
+	gd.IterationLimit = 1000
+	res := gd.Descend()
+	fmt.Println(res.Reason, "after iterations:", res.Iterations)
 	fmt.Println("error: ", gd.Err())
 	fmt.Println("U:", gd.U, " V:", gd.V)
 }
@@ -41,11 +52,46 @@ func q7() {
 	cd.V = float64(1)
 	cd.Eta = float64(0.1)
 	cd.IterationLimit = 15
-	fmt.Println("iterations needed to fall under the limit, ", cd.E())
+	res := cd.Descend()
+	fmt.Println(res.Reason, "after iterations:", res.Iterations)
 	fmt.Println("error: ", cd.Err())
 	fmt.Println("U:", cd.U, " V:", cd.V)
 }
 
+// compareDescents draws the paths of gradient descent and coordinate descent
+// on the contour of the error surface into the file given by the svg flag.
+func compareDescents() {
+	if *svg == "" {
+		fmt.Println("no svg file given, run with -svg descent.svg to draw the descent paths")
+		return
+	}
+	var gd GD.GradientDescent
+	gd.U, gd.V, gd.Eta = float64(1), float64(1), float64(0.1)
+	gd.IterationLimit = 15
+	gd.Record = true
+	gd.Descend()
+
+	var cd GD.CoordinateDescent
+	cd.U, cd.V, cd.Eta = float64(1), float64(1), float64(0.1)
+	cd.IterationLimit = 15
+	cd.Record = true
+	cd.Descend()
+
+	contour, err := GD.NewContour(GD.Surface, -0.5, 1.5, -0.5, 1.5, 100)
+	if err != nil {
+		log.Fatal(err)
+	}
+	file, err := os.Create(*svg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	if err := contour.WriteSVG(file, gd.Path, cd.Path); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println("gradient descent (red) and coordinate descent (blue) paths written to", *svg)
+}
+
 func q8() {
 	eout := float64(0)
 	epochs := 0
@@ -60,7 +106,67 @@ func q8() {
 	fmt.Println("logistic regression: number of epochs: ", float64(epochs)/float64(100))
 }
 
+// spreadData returns n noisy points labeled by a line, with x1 in [-1 : 1] and x2 in [-1000 : 1000]:
+// y = +1 when x2 >= 1000 * (0.3*x1 + 0.1), flipped for 10% of the points.
+func spreadData(r *rand.Rand, n int) model.Dataset {
+	d := model.Dataset{X: make([][]float64, n), Y: make([]int, n)}
+	for i := range d.X {
+		x1, x2 := 2*r.Float64()-1, 2000*r.Float64()-1000
+		d.X[i] = []float64{x1, x2}
+		d.Y[i] = 1
+		if x2 < 1000*(0.3*x1+0.1) {
+			d.Y[i] = -1
+		}
+		if r.Float64() < 0.1 {
+			d.Y[i] = -d.Y[i]
+		}
+	}
+	return d
+}
+
+// learnBFGS learns logistic regression on train with BFGS
+// and returns the number of iterations and the classification error on test.
+func learnBFGS(train, test model.Dataset) (int, float64, error) {
+	lg := logreg.NewLogisticRegression()
+	lg.N = train.Len()
+	lg.VectorSize = len(train.X[0]) + 1
+	lg.Xn = make([][]float64, lg.N)
+	for i := range train.X {
+		lg.Xn[i] = append([]float64{float64(1)}, train.X[i]...)
+	}
+	lg.Yn = train.Y
+	lg.Wn = make([]float64, lg.VectorSize)
+	bfgs := GD.NewBFGS()
+	err := lg.LearnWithMinimizer(bfgs)
+	return bfgs.Iterations, lg.Score(test), err
+}
+
+// scaledLogisticRegression compares logistic regression learned with BFGS on features
+// of very different scales before and after a z-score scaling fitted on the training set.
+func scaledLogisticRegression() {
+	r := rand.New(rand.NewSource(1))
+	train, test := spreadData(r, 100), spreadData(r, 1000)
+	iterations, eout, err := learnBFGS(train, test)
+	fmt.Printf("raw features: %v iterations, Eout = %5.3f, error: %v\n", iterations, eout, err)
+
+	s := scaling.NewScaler(scaling.ZScore)
+	if err := s.Fit(train.X); err != nil {
+		log.Fatal(err)
+	}
+	scaledTrain, err := s.TransformDataset(train)
+	if err != nil {
+		log.Fatal(err)
+	}
+	scaledTest, err := s.TransformDataset(test)
+	if err != nil {
+		log.Fatal(err)
+	}
+	iterations, eout, err = learnBFGS(scaledTrain, scaledTest)
+	fmt.Printf("scaled features: %v iterations, Eout = %5.3f, error: %v\n", iterations, eout, err)
+}
+
 func main() {
+	flag.Parse()
 	fmt.Println("Num CPU: ", runtime.NumCPU())
 	runtime.GOMAXPROCS(runtime.NumCPU())
 	fmt.Println("week 5")
@@ -73,8 +179,10 @@ func main() {
 	measure(q5, "q5")
 	fmt.Println("6")
 	measure(q7, "q7")
+	measure(compareDescents, "compare descents")
 	fmt.Println("7")
 	measure(q8, "q8")
+	measure(scaledLogisticRegression, "scaled logistic regression")
 	fmt.Println("8")
 	fmt.Println("9")
 	fmt.Println("10")
@@ -11,7 +11,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/santiaago/caltechx.go/crossValidation"
+	"github.com/santiaago/caltechx.go/features"
 	"github.com/santiaago/caltechx.go/linreg"
+	"github.com/santiaago/caltechx.go/model"
+	"github.com/santiaago/caltechx.go/modelSelection"
+	"github.com/santiaago/caltechx.go/pipeline"
 	"github.com/santiaago/ml/linear"
 )
 
@@ -72,89 +77,110 @@ func getData(filename string) [][]float64 {
 	return data
 }
 
-type nonLinearTransformFunc func(x []float64) []float64
-
-// non linear transformations
-
-func phi0(x []float64) []float64 {
-	return []float64{float64(x[0])}
-}
-func phi1(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1])}
-}
-func phi2(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1]), float64(x[2])}
-}
-func phi3(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1]), float64(x[2]), x[1] * x[1]}
-}
-func phi4(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1]), float64(x[2]), x[1] * x[1], x[2] * x[2]}
-}
-func phi5(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1]), float64(x[2]), x[1] * x[1], x[2] * x[2], x[1] * x[2]}
-}
-func phi6(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1]), float64(x[2]), x[1] * x[1], x[2] * x[2], x[1] * x[2], math.Abs(x[1] - x[2])}
-}
-func phi7(x []float64) []float64 {
-	return []float64{float64(x[0]), float64(x[1]), float64(x[2]), x[1] * x[1], x[2] * x[2], x[1] * x[2], math.Abs(x[1] - x[2]), math.Abs(x[1] + x[2])}
+// extraFeatures are the features added one at a time by the transforms phi3 to phi7:
+// x1^2, x2^2, x1*x2, |x1 - x2|, |x1 + x2|
+var extraFeatures = []features.Transform{
+	features.Product(2, 1, 1),
+	features.Product(2, 2, 2),
+	features.Product(2, 1, 2),
+	features.AbsDiff(2, 1, 2),
+	features.AbsSum(2, 1, 2),
 }
 
-func q1() {
-	fns := []linreg.TransformFunc{phi0, phi1, phi2, phi3, phi4, phi5, phi6, phi7}
+// phi returns the non linear transformation phik, for k from 2 to 7:
+// (1, x1, x2) followed by the first k - 2 extra features.
+func phi(k int) features.Transform {
+	t, err := features.Concat(append([]features.Transform{features.Identity(2)}, extraFeatures[:k-2]...)...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return t
+}
 
+// validate learns the transforms phi3 to phi7 on train
+// and prints their errors on train, on the validation set val and out of sample.
+func validate(train, val model.Dataset) {
 	ks := []int{3, 4, 5, 6, 7}
 
-	data := getData("data/in.dta")
 	for _, k := range ks {
-		linreg := linreg.NewLinearRegression()
-
-		linreg.InitializeFromData(data[:25])
-		linreg.InitializeValidationFromData(data[25:])
-
-		linreg.TransformFunction = fns[k]
-
-		linreg.ApplyTransformation()
-		linreg.ApplyTransformationOnValidation()
-		linreg.Learn()
-		eIn := linreg.Ein()
-		eVal := linreg.EValIn()
-		eOut, _ := linreg.EoutFromFile("data/out.dta")
+		p := pipeline.New(linreg.NewLinearRegression(), pipeline.Transform(phi(k).Func))
+		if err := p.Fit(train); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		eOut, err := p.EoutFromFile("data/out.dta")
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
 
-		fmt.Printf("EVal = %f, for k = %d\n", eVal, k)
-		fmt.Printf("EIn = %f, for k = %d\n", eIn, k)
+		fmt.Printf("EVal = %f, for k = %d\n", p.Score(val), k)
+		fmt.Printf("EIn = %f, for k = %d\n", p.Score(train), k)
 		fmt.Printf("EOut = %f, for k = %d\n", eOut, k)
 		fmt.Println()
 	}
 }
 
-func q3() {
-	fns := []linreg.TransformFunc{phi0, phi1, phi2, phi3, phi4, phi5, phi6, phi7}
-
-	ks := []int{3, 4, 5, 6, 7}
+func q1() {
+	data := getData("data/in.dta")
+	validate(model.FromData(data[:25]), model.FromData(data[25:]))
+}
 
+func q3() {
 	data := getData("data/in.dta")
-	for _, k := range ks {
-		linreg := linreg.NewLinearRegression()
+	validate(model.FromData(data[25:]), model.FromData(data[:25]))
+}
 
-		linreg.InitializeFromData(data[25:])
-		linreg.InitializeValidationFromData(data[:25])
+// crossValidate compares the transforms of q1 with leave one out and 5-fold cross validation
+// on the whole in sample data instead of a single validation set.
+func crossValidate() {
+	ks := []int{3, 4, 5, 6, 7}
 
-		linreg.TransformFunction = fns[k]
+	data := model.FromData(getData("data/in.dta"))
+	for _, method := range []crossValidation.Method{crossValidation.LeaveOneOut, crossValidation.KFold} {
+		cv := crossValidation.NewCrossValidation()
+		cv.Method = method
+		cv.K = 5
+		for _, k := range ks {
+			newLearner := func() model.Learner {
+				lr := linreg.NewLinearRegression()
+				lr.TransformFunction = phi(k).Func
+				return lr
+			}
+			res, err := cv.Run(newLearner, data)
+			if err != nil {
+				fmt.Println(err)
+				continue
+			}
+			fmt.Printf("%v: ECV = %f +/- %f, for k = %d\n", method, res.ECV, res.StdDev, k)
+		}
+	}
+}
 
-		linreg.ApplyTransformation()
-		linreg.ApplyTransformationOnValidation()
-		linreg.Learn()
-		eIn := linreg.Ein()
-		eVal := linreg.EValIn()
-		eOut, _ := linreg.EoutFromFile("data/out.dta")
+// selectModel selects the transform and weight decay of q1 by 5-fold cross validation,
+// retrains the winner on all the in sample data and measures its out of sample error.
+func selectModel() {
+	names := []string{"phi3", "phi4", "phi5", "phi6", "phi7"}
+	var fns []linreg.TransformFunc
+	for k := 3; k <= 7; k++ {
+		fns = append(fns, phi(k).Func)
+	}
+	lambdas := []float64{0, 0.01, 0.1, 1}
 
-		fmt.Printf("EVal = %f, for k = %d\n", eVal, k)
-		fmt.Printf("EIn = %f, for k = %d\n", eIn, k)
-		fmt.Printf("EOut = %f, for k = %d\n", eOut, k)
-		fmt.Println()
+	ms := modelSelection.NewModelSelection()
+	ms.CV.K = 5
+	res, err := ms.Select(modelSelection.Grid(names, fns, lambdas), model.FromData(getData("data/in.dta")))
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	modelSelection.WriteTable(os.Stdout, res.Rows)
+	eOut, err := res.Model.EoutFromFile("data/out.dta")
+	if err != nil {
+		fmt.Println(err)
+		return
 	}
+	fmt.Printf("best: %v, EOut = %f\n", res.Best, eOut)
 }
 
 func min(a, b float64) float64 {
@@ -218,6 +244,8 @@ func main() {
 	fmt.Println("2")
 	fmt.Println("3")
 	measure(q3, "q3")
+	measure(crossValidate, "cross validation")
+	measure(selectModel, "model selection")
 	fmt.Println("4")
 	fmt.Println("5")
 	fmt.Println("6")